@@ -0,0 +1,56 @@
+package godgt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ScoredMove is a single move, and its score, according to an engine.
+// The score is in pawns, from the point of view of the side to move.
+type ScoredMove struct {
+	Rank  int     `json:"rank,omitempty"`
+	Move  string  `json:"move"`
+	Score float64 `json:"score"`
+
+	// If the engine has found a mate, the number of moves to it
+	// (negative if it's the side to move being mated).
+	Mate int `json:"mate,omitempty"`
+
+	// The line the engine expects to follow, starting with Move.
+	Pv []string `json:"pv,omitempty"`
+}
+
+func (sm ScoredMove) ToString() string {
+	score := fmt.Sprintf("%6.2f", sm.Score)
+	if sm.Mate != 0 {
+		score = fmt.Sprintf("%+5dM", sm.Mate)
+	}
+	return fmt.Sprintf("%d. %-7s %s  %s", sm.Rank, sm.Move, score,
+		strings.Join(sm.Pv, " "))
+}
+
+// PositionAnalysis is an engine's view of a position, with its best
+// few moves, as it stands after searching to a given depth.
+type PositionAnalysis struct {
+	Fen       string       `json:"fen"`
+	Depth     int          `json:"depth"`
+	BestMoves []ScoredMove `json:"best_moves"`
+}
+
+func (pa *PositionAnalysis) ToString() string {
+	lines := []string{fmt.Sprintf("Analysis at depth %d:", pa.Depth)}
+	for _, sm := range pa.BestMoves {
+		lines = append(lines, "  "+sm.ToString())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Json returns the analysis as a single line of JSON.
+func (pa *PositionAnalysis) Json() string {
+	j, err := json.Marshal(pa)
+	if err != nil {
+		return err.Error()
+	}
+	return string(j)
+}
@@ -188,13 +188,15 @@ var _escData = map[string]*_escFile{
 
 	"/assets/html/index.html": {
 		local:   "assets/html/index.html",
-		size:    302,
-		modtime: 1476249402,
+		size:    609,
+		modtime: 1792223149,
 		compressed: `
-H4sIAAAJbogA/1SQXWqFMBCF313FkCeF0tjXOrqGUrqB3GRuTVFHklEqpXsvaSIX3/JzPuZ8g6PM01AB
-4EjGpQMARhv8KiDHSr0S+hb9ZXaTX1XOANy3xYrnBcTP5N7pHiiOdbrwJm8UPLsGfko4knzkn1pNbE0C
-nwNNbFwtYaOmU09XtCvkb4U6Tx4qvLE7gJeE9erR6fVS4aVt26ZTAwDqU+qfLHbO76cD+vkTYrC90jc2
-wRU51CWDOnOo85r+AgAA//8KMNmJLgEAAA==
+H4sIAAAAAAAC/5VSsU7DMBDd+xUnT65UxWWlTgZQBUggoaoDqxtfW6PEDvalECH+HadOGgET2/nuvbv3
+XiKPVFfFDEAeUem+iGUovWkIqGswZ4QfJF7VSaUuSxiAfWtLMs4CmRr1Bvcew5H3D9fSM3rj9Bw+B3BA
+2qYJZ5UrVU/MPFZOaU6+xfmKLX5SVwPzayZFulzM5M7pDpztaTmbNF3/kHC1XC7jviIaEaOpM3Nwp81p
+9CBNfYDgy5yJnVNeD+akuGBk4xFMvKasqrpgAiukiL3/JHVSHjy+tRgIcrD4Di9Pj/dEzSY1+cXsgMqS
+xQgeQ+ZTlNqVbY2WsgPSusK+vOkeNJ8UzrNeya2zFGdxybg1ptM4G3Abp5d4/5xu0HJ2t96yBTAx7fyN
+C2j1KHz6Qn2doo7Zn/+sb8uvhf9hAgAA
 `,
 	},
 
@@ -5633,6 +5635,557 @@ mMVPm+vTzD1Lr0buKZIirpU/Zu5vokl0z98AAAD//zq3hZCPAgAA
 `,
 	},
 
+	"/assets/images/draughts/128/BK.png": {
+		local:   "assets/images/draughts/128/BK.png",
+		size:    2811,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/31WZ1ATCBNNKDGhCIFIYqOEYlCaIAJKE0PoUgWpEgKHSJFOKCKCQChKuWikNynS5BCk
+hBa6BE1odyACQXqPQaUJHN/M9/vmzb79t/P27c7OJpjd0ePlOscFAAB4DfTRFic56n8BBp1wlyaWCgBw
+dRqgb1nhs9cdNR5YTcC1fJ5xXCDk90QBLTl4KlMxZvROXYo1HWnflGRvDFVwxhbE5jtj0647zwZLO5jV
+0HUI+fy3KC7BCbH56cBY815GB0ow9Enk+63N41B/pYyQmaYt3PSe4+mWjNCtqQCaWkA7rUkrrXDUjP2/
+gEoBrl+DS3ZIQO8mlOmgBReN4kDQ+oRldmir8v1yIIwKOC0wBEXuZxBKumiChyITihTRI6lCG/QPPqgG
+t5bngHkmslAN2WyElkVyqVteGfSUG7RPAzJpbRh0hRBnsUmFmPxIsl6sQi+hDIO+IiQ5H2utI9yH2Erk
+0O7bwBiOkWH1OrkVlTKJiCimEKEMB/U1LxN8dYtXLE7M+XVwPSNxaRRCtcK63VUYFBjK1I3q0vHEnj3D
+gyidaQB73VuJjG5KKOjyotzAW4xeDveO+sX8oKvgVjTptn7eGVTlA8XYvnUqse3BoUfrwAXrozxuSqOq
+RmhHo9mXR8U+qKKC7pbL7e9YvEG3bXnPLMpy3l013S7/zbqspSw+jpVTB25MWAhfz01OSRkZHYWZGBvT
+N6fIb+2qhsfH25OC77dHFvOJaNKXaHlvhx7Q1/555/H48ZR6puR1wrINcp8aYS7Brp0SrMa9kJKcTC+4
+Hb+8+30ucH38r2llmPApBQJc3ok+Umq68n2ur3Vta6Y9K2+EI5kBR4Y9bEOxldQiVnNU/ZCNQWvWdK/r
+7B6MHLwKq2ZhIyNTN/NS2Ru1j0qamsIOzYHS9+ztlZpZp+dj1dManl8ytdq5ilsugnSMLj5ajJHPN2n3
+X+Aae6hcbrVPdcPhYpxaw8aKGh7NDPeliIR7t+1dhJh0T7eGe/j7p5eUlobXBbgf/10x5TPy7RvSsT1S
+o0qzKw/9g7yXqjdoJ2NDxGAw0dxwOY4qa+pvsSo8a97Ad4+1wFgeKqIsL5f9dDwE0xi2Lexbq6eYbLkd
+WRuOncRJzjeQmYowJSUlCpUk//yrCjYoSLkR/92l5tGM5tq2AXirm5zWZw14xBj61SJaz2omrgdpiHj7
+NEwq1x4BqVQq5a1laSVtT/SkQW9ACmPsV7bolHutyHT5k3+qHSkxp/iSI55otzrRym2OZsrY1ccBuRTy
+RhPWk+ty2we/jZn2GbuF4Bs5TnfzLLImSR3seKDpBcZFtxqNS9qemhhOuZXy2KbuQEbHU8bhwQ7m1fHs
+3vZS64wXzdWAAWnxy+cR3msdCEy7Ua4ZvOVTHGHcPT97v/3MUxXm60JoYO20f5sz9jK0YKBOWmSscuNI
+XQC/oJ2hrnyK2/hSz+FO0Uj3ZMSPclq2Gq5Vi8aRu1fG6bZNvzpYKdSIxUpBJ8IOdK3axJifnyp+2TTR
+V3nt04IzDhs+hVtC2hz4ZbLzbXNU1V208BSSyoa8d29pRrsPBD+CJoTwmFvzKcUpl4i/4w8YwtAyTLdc
+VBfeN6SERgb2nxbR/CruQK6E8xiiuEWQhYOdbg7YG6w4D6PFz890iSiFAqSG65f37qa+R7/3nD08pPHb
+i88/PhRknt+ktOJ7f8nZ6GTsukIHfMgqwGKBN98yJXGXOsNu3B/702vH/NN0U4C75baifqcS3rq2fPEQ
+SHTEm1KUS67I83NT/bcXP5kEHAiMIcsDUaZceFI3QthtzvNU6tMNJz07t2aBPqKk5aXohSd7rJ7+ApKE
+UPDnnJsNK60CkJMV8A7tFSNe5WqxaRRiNgd5fU4v6K/ho1Z8OABJ/iYLCQkNrrUAQyWqinctapk1vi9W
+jGEhsmdvQGe6SK4aIlxplawGdpahCwfKE5Yau6Gp10wNlOJK+yjixOuSOBo8k0/s1cpjD/2CP0vcJTYw
+2uKERjIQp+0UBTMQBsPbeuTsM+k/ILsEbfy3NdGRHPBRjSssyyyI9figOnyXrrQzNHse1j8ZFBilqAfM
+kNiwRy/zlZB/yxy6LMpyrTfWT6mAiyc2H3Eynee8+b+Rn4udn3ysSSLMPUuCZ7hFk61hJ1fiGukvBSWl
+2+60P7YM+YQ7krjxE01eAs5f4oGEuivMsSS2yBC5KEt9J8YAt/xNkgkbfoV9vDxzHDa1EbNiTdJFDI6Q
+jbf22fB+tje1J4oWory8dtTSH/AqfNS8Cdx0MJd3bHz4+ODXp4gLiFLTvOm/va+YRMGZMDjqpqszYEIb
+o/OaeA2QJqvHpKUg7XZVVUNZb5oGeR1CvykyFA2jyt8ACetaer5xKEbZSrS43ouaR7XxV0wdQA4YiFna
+NQC0mj9DnAu/0tuzvPyAFvNPxGfuDkF8Erccu1wRx+vUk8rdRYG+cG9SyDxErmXi6+gIuhzk7MJ6Iztf
+J0rJepbs7wnIZJEslAHoOUMu0h/isvpMKbGir+cA8FSjl9Ji83UDS8NebxzbbvHmi2EBhC44pwurm1PK
+1CE+RROY6yBV38jTmCyQL+YB8Iawa/P3ohziA2i5GtvXswNi/6+T/upERhgEqlqkLSEHVw/70e+wCKpW
+9zvre1YyCm/vYu9L78lPlwEl/GTn9x8DJKHAc+fgeZqPC8N3NjNSMthbokobT5xNsjOfkEB3nYwlO/P5
+OFkgS5Q/+6q870IsKYcqBTz6WxbbLOmVf8LSdfNZOnPXgEic/DOHpY2d9Ur7fnLI1vTQ12wbvddtkNCY
+U3Cm4m2p6Nll3cDiF2KrCFYMjtAc9/KrMk81dvWCnf+SWRCffFN4I4iACo0C80ItWfxXwdCiW4WGwhKG
+Xd0v1Y2zQYkyofn6HImjZn9J8p2poBQaCcvYdfWQ1B3UO3uSZKFrT6E96gqdRrLOccZhrsZXXyQP3S4y
+jHxAFD5vw8LN+XplC1+wZcWI/1weTxe5mFSEM9GOfhXfuTo7xKmm/sXTMr+v70BNMzK+ib/bG7n/KYLJ
+iR7RiWizq8K8NDxuo/f2YuQRguA72WBqZ6vvbDG3nsnuKsWRu85RojaYbJKUlKTRHvXzZ9B2velvA3R7
+Fl8oZ515w71qeCeWm9BA20Hsc7B0cjs99ybNNUxbjzYEPkgqy8YyDcFNLxIQmyGiwBbBrc8PRod+1I9R
+9QSdoxpLqei6gNQsyZH+fn3a1ulqG3B74lojBUVHd9jwbdqBVxI9KY8P/IZB0EO2dze2dc15AJChbFKt
+AtvpH2f5n0H9dvkTeUFYNkUxGW7YCwYPkxafFGzovR8MGTh+SThSvA+Dbr+6bOKhkOleayWOSoPyPGeZ
+6DxtfhV9+VB/CmvVZuA+Bt4/d0uFOGQ2d0/odjebmRLRRFrh5aKrObBs0zM7b8kKUvrcm01acEpumCcv
+5dpa+JmrpaPpTuPS/pnM1jxR90AtmlGzbYnt/Umqv47n/rvj4/bICiuIys1gdWkx7tVnbuVEh3b6VkdZ
+XPh0khc4p4ZnLLu/LrUsrtpUdxX1QSf37ncIjDZybxlIRlbKGNhBQi9Q9THFlEAEL+7uvadydedYijm1
+PDCxks7+eyGWD6XssE4BXQrhDeI5XhYmqqpnS1WPLhxd4ZNCBKh0dlBVp7fzFNhKSdqDDwH+oJozUl13
+bItTbl3r/PN6jZvqk6VhFGx4V/pI/CHgv+ADSt0bOwYBFEDJiJrfLkMnTy3AQPcOulrHOfpfWbzytfsK
+AAA=
+`,
+	},
+
+	"/assets/images/draughts/128/BM.png": {
+		local:   "assets/images/draughts/128/BM.png",
+		size:    2532,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/31WdzQbihuNlajGSGmshzaU1PopFSEEEfUQW2MUNWrvrcajKRqrtVqUmG0oKsIzqlaN
+8sSmqFrl2bNKNRrtzzvv/3fu+e53zj3n3j+/+yWZGutxcghxAAAATv3fsebnO+6fYQeeczfaiQYAXKjS
+x2pbRhTs2Gt6WNL40W4sQLsZPuhF+Vznd2yxji5fOvnQsn9OwyhR3A5U3c64hITrrCxQaCNTQuueMjfs
+xag84YG6ae3MybnpQbWAtROnfHWtzElf0/B3v8dZRmH7g2cq68Lo5vDgfVJesPHe4Dm7JbM66IC1/gOd
+Yiy5UyBRx8fEAaylRGnyz4wSXaw9bFaq1P6sTECrEbwUiz3ihdhLlD5Xo6S2r/azPvz+iBhG3LgLO71D
+MjQwq7w0BoO85jV7RGTu26lohd5eILDG/doBYSdvwuF+iNs2XtWpzLTcq6VqEF/h7HSoX5XicG3dRRhA
+pOnRjbYFX2rZgJ1bKCSViX3/BuQYzDzMEVXJ5czuIsCMnthzt/tSoHG7HFZVFef0gPgNzDx48cRShA38
+3XhRgV2WMkdUQGSgZO9VP3RPEVlcBHpwT4MJX9K80d84FQhTCuzdVohoodsFUqWN2uzt9a/vZXnPwUrb
+Sw67/HdkBFcvGZYyMgamd2RsVjkME5M59IL1s/cphy5KBIvTTsho4VleYoLUS/Okyg/St6UvPyeRXga9
+wSkpLUrutkfTMT9+/DjanJDyarA92ptHqSGRo02SSUyQMww2dlOs7h5gY8xPsoSBKdHK+d9dcpUVZZzB
+YBwIcIiBFIgq/gujU1PQtmh6UATjeNvqbB3JJP49j9h4xG2jpZxk0uY9XcNmUqh+YXY3zFE4QrIwTR13
+H+WGMlxh7UWC7hu8NA842Zt39Pc32R5QTec/xC6QFVWdu3uJJSmSAJ38ji1eK6389tZW3HEkKbl3LEgQ
+DPyzvl7p7709+RjG98CZte0O8ZIkZNQ32aGhIbPXeOGgyMiQ7Q+7YZ6hoU/xePzylhJDG9uxmXXT+Z3j
+G7wNnI/wqckfRDLW+6Uj9GXrQ9XSQlvUu6cyVoSJchPhIuXkZtDJsmDEFLxYFs474Bb5zJUcxvxqORK/
+P/7S8N25tWEuVqv+I3hv7g1vASp8OCKWbzkvAnGI5IALD3i0LMJQniSDMLWiJ4imxX1vG7GutTW3gLPT
+0JUYeWJrevkFuPiAV8sITCV35+uPLc3gNdwBgx4st6jjcVNI/3igIza0GCxZPAh/u9neqsyma0VrHi7S
+9I9e/RbZ9rkKTUaES5gBs9lcl7U+moEmYqDN68tVjpkhi6bN/a0BnzEHn7tBcrT9iLPT4wN/clSmxEB8
+XWAxFxctqmgTFpZtX72gYUAXIOp3x56ZYT+avhdIK845yvzal4jRhTnkSa6uKHu3oNOwNqqmUnpD71zI
+WTs/T8omej5FH1X6L7R6tqKHsHl0q4tba8UuuTN3lkpKFDI8tnbdzejP4SjQlkcLAiamOI/sS93VFotG
+udMbNI2DlxwLinmbdLzmTYlJSWNKP1uw7gMhvtC/UIkVVQ8h3WoTGRbWwWO3RvK495eDpYfazgKTWg5M
+sJMfg0Sa8h4XlLj1Rzs7X7oGBlcgGX7GqRW8YrKYeaPpW46y2tKiAoqFxFyT9frI+x4nJrNiWvNylOsR
+nSGrrFNddeqRRxuPqGfqbaeqZirocIBgbg0p3Ygss10hpEgi1jJGijS9VcEWmbyoQEgomEWxlgENaAnx
+GUsveTqCxlGe19DE6ZZ1qyNdumSlKmuVe0gIXagduf41S3JLCHjPqcUPU6NEstHt6/Xm0XlB3j7Eof/2
+pdK3FUg2KZdT2QvqTyU4oFAuimvE6dEr9BGwddhOEqJQFeIq0iFc7etTKjU4vfvWujsnrHTYSUsS2hH3
+h3tL/SGwuX9Yirk1pJZhy1Kosr/YMV2+AAi9Na98hbf//SX5FgWATBkuYJpjpTeF/3QUddAS/BGrNZTA
+ZO1ptVTFD2TsRbNRUDlGrMhNltzZ51RBh92HNlT9AsQhZlJD/SLHx9ya/HT8RMHVh9QkCEVBQdarZVjq
+sMQppYwQjHuU8RwgY/h4qUgMGH0cDcphyjFjzfYVint6vM4+zAP8TTnmX43vH838qqKewMH4sYgd0O4W
+xJTqCcBfejL+zv7PmPi/jDSMe7wAapJPljx65MqsObXMT82rgXEedoQx4p1bFuiGcE/cPzbPG3HPOFm0
+fmtX6MrCtBNocTQ4sy2Cs9i7G8ATfwPMeu/w3/TRhvP0BUNipyAQFkjnkmeRL2OvXXiW+z7fVRG+kqVt
+0fgpe+USpsbrCldRry5PnmFFFBLqZI2AjAU5AfBhQ1wUArWVHRacNE2iSTL/DHQCoOSh2nPNgTpikSCH
+cPl89tpSGRs+6RQMtxzH2R0xLBOmRjPq2+CHzI3BuGlBRarA44Xkmp4bPSx9PIGcvNO1VLvuHtXJ4HXT
+QO6TOQ07ZmyE6BUMpidCFAa86izxe/a6dqkErmDdaVnC9MX6AxgEbo7pjRSVxhN0o0Rlbbt6o0TlRT8v
+u3azj6ikBhxCR4twda44RGNqhlGZ+uJNg8a0r9GfkD7CUsJ6ke2BL3xEtulT1Bk1PEhiA1lR7SNKyhgq
+IyHY6mJa83dXTDZZUqzFS+8eJ8lkG1yzdXAgR7YZ9y5vtI86KggAXZghWSAnG1zhQMBkPEV0rqGzdiLx
+wrxfDt7KytPVNYGLi+toRP4LCHJGNhnn8ChzqrWTwlor93xa9wbbM9+/akILpvdRM8gOvwIv0DJnEw2Q
+1zA1LlcSf4jMAAqT3KWOPoyFNUzSdHmLHzSX52NvxmSm+JIxJQpHbx9uX+6iLEfMOfJ5wJaqQOFyETtL
+M8stZ4HjccQ/Eq1IP6/KAONCUog/cV3syFF3LZ5bPZ914AiBeK7++FpZUyRBqVNTTH72RsNm3zX4L37Y
+qeGvdF1ZQQfPrqzApL/MUzvFxfvTDkMxCafnbcddX6S3uh1imOr4AnL0NDUojS6Bhih1Nmam8Ve8V7XO
+ZkLYxayXq17+UEvVv4IOlDMUj5YfJ2zb9GTXuUQ5S9HO77uG9kBzxtr1eYkJQtWq6/XPhYzpXyd3Yy2l
+K3sfa+gZ/E44r+7S9dOEP0xS62y/FrrqbLxSy7aATeBKq+2K6ibNdioUU5bF4YXlgqtMb31tbBBvXg8g
+s9JTW7WNdLs9bPFLEj6VdZPOzx5olVTCXF73TeJCLB1S7gu0oS6bIKiSPgWMrFlMG3lY6mzN71Unjdj2
+g5Lc6XnNo1EgDsWC5AiutMD/5sQj1zABfAamNZIJDBcvxp0ewH9BUimu58lPTUC8IP8i3lif7fx7A+jr
+GmNrMI6E/wPTZLQ05AkAAA==
+`,
+	},
+
+	"/assets/images/draughts/128/WK.png": {
+		local:   "assets/images/draughts/128/WK.png",
+		size:    3564,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/31XV1QT2tJOQocghIBSpPdQjZLQhAChFwlNUEIRQ5EuLZSDoYhBeq9CKKJUPYiAQDgJ
+NXQLoIggIMegINJBmpfz3/vwr/twH/bstb89a82e2bPmm0m2tjLmZBdkBwAAnKYmaJuznfDPYmU+k706
+7iNn+AtTtJ4dvnQNa+nNNwRV+Q0BS3HriQMZ2KTcKIR4kUTGHTE5PETiYC8X66zKQiVwRG8+5xZXVF6W
+4N/IJMu3V2YmsFxlFVzSszbJZ2U34lHlWRT4xC2e9WtbqKa3eu3XSkYR8qfl7+L4fRf43NSU2gkGiz3R
+vSunVgpSZwpiX+ClyBNsAU8AVsD/Av7GEOaWDNheW6KF0ZNm6EdLxkk4icMMogPRG3O8wkvq5iWVE9Oh
+b8QlvnN0Rwzo8hSRuLfRO0nEU+nKC9SIAvbcqOQVOOSqWCUMEilgqya3ete61YCTgIxOJZ6X4sxFKjF2
+7VwSEVasKiIaEb0d3rIaVswQBj0HOhOAqsFCE7XRbYZTBPo5hwssTCg8FN0iDgkivzayftAD61HerEBR
+4D+QEodz1Qr+vr5Vp6exmneXWXf39mwiIpBhoaEHLRE/Xcp7Drb+TrmmoKCgo6Ss/LYz4ufK7ur7K7q6
+13x9fZdcwzbSiKdSlX/F42rMIbX6aPNspHzW3o9ZOrY9iNoZtpqPRCLpM8xb6Hk5BtwQ3kTPXE9YmP+5
+VXk+q2L+x9lZssL1JvxE2dVtBBJpeBN3Lvc+HIPAZ27mo94xtyYnGRiGxUfsrTkFRkVpvjfKMFsAdhFk
+qa5mdflkJMVRVxqSmru3NvMF2xE6FH2015j5xyJgniKJz5sSj68BOeWyPyeNmSZuMqipqUVeUHHZ0Yk9
+llt2xhOub4DncWHZr52LGh4moFtgkB6u0MMdn0B3dz3ay3XAPIbFaU6qRZBWUxFcRkRI533WK3ScexVi
+TPtzPa6julQxgBl+xyS1xue4okL82Y1G9hk1sLXspKWDl1FJXIMge1X2w3VSfv75wDt3zGj17nGalU1w
+AVVBJcsv+zs7t291yd/Rx2K8ihS3HOIbLKn8E+t9GmVgMPh17wMun9+nJ0tPLACXVSw6R0ZGZmU+B352
+q5C+ExAwj4BEhIcf1ngEwFrTJsp1370KoXt7ehodS/Weh5yg0b+Jot/ZEkw9dAb6FFM1aCHh4dNCoqL7
+nFc0pzW1E7yyWLaGH07wbsePRTkL78go7SY9eNDIsbv5ZbB7jEJxEDWlQSOrwCUEsBiHU5H4jUZZLT3f
+7CwWvlam0abNfBH1INQNh/OJzdY1Y1Nm7NKFIz23CvsvAmrGyvsvpJ9FoSN6X/nHvP/XjIfBx4e7q1c0
+NExXV1eXvOSLi4ufPns2IevH4dEdd0kX7Af0cB5AbJQgA2MOT086D17wKikpXYHD9d83YY/co53U/P39
+MY9tKo72f6aU3z4oIcZCXB8SH6JD2nzc3Cjts4PbUCjU5ebNkhawnpyvn9/oZ/lPHz5wZzxhLqO+oDFE
+Dg1zM7pQctI6pPPslzmrx9uxVxWjHa5fN8S+3Pg+pfBzY7H37fHmLDWBhSyEDMCbm5svQXxRZTQhHG2Y
+OQ654Q2iybknOyp9d7i7HKsDFq1ZjUl9hSlRkLUq3RC5GiV1qhZCUsyV/8KkHX8dinJgG6j0kTm6FXEi
+c777metfRgFPL2qG0g4bnKUr/DbEvnBqJ0RgUZ7NMSnqvC0Dr4LdH9Wn7P/8bN7pvNZWpYUQDvEHaBMu
+FHEB8MbCoVluko7Dt+xJOaeZqm3+Fm+wMHcFe0+KRtSOt3gbkQrF5wyhClxpylTiLotvyqc3EHprY+dj
+oakD1T7excYMEJ4hA5EHgIPp/d5V08p0KE9N6yAKw8ZHGslV0+mYiQLi8+Tj1oMyF+zg1I/Ve4G6nj2a
+ghmmUNHtrPfk6P2+vyY5KZddX5ICNKEGNPgdJrsRFzfnntHw60qFyKmdKL8Y4EZAvVcup5K4oJd/jkZx
+VapOIeeqetCtH84Tc93DQSQPWYKFR1davV7JchKEDapDVbrB97xsU9mq7YK8xMFsSsd3R7D4bJBOqpGj
+EO4hTfJjXexRoUbItnK+rdP4njt9MBF8dCwWMz2jYsIxeV8FgvGa0tRvXdTmH2tGDIP/yO5pu7bG6vkO
+VCK3qP167JtyUSXg6PDHbNvJiwQsFqs1zAE9TfpN/V28fjOIZNXFYTxnnxUEki8Q4/PIjv+jByLPR3lN
+jdj1Sz9a8XS3TCnSM+Y5y5maEq3gbeAkdsqaWeDBttuVgY8wqElvs9uj+o+PseSoMGOR5/X1X8UZOnZ4
+mxxq2SKLCkOZrJHdEQCBp4BI2poUUOl5a3O6i2mFzmJ1AzvpV8zgyMBAevzsS56ZuzeNhSO768iA28Lj
+CZiaJo8CaaZDRjpbDk609bolASmpp+rAiivcs4lRcApzYnV6UO4VWvSyyF47k3dFy6KO/f7RRtEa/clO
+00ujmvnRp77qujR9Jjmtgc1doO/l+kzBe6ohiG/M7hJqeiKB3d8yB6mW83W9ordFYnXQ9tOe069V5Edl
+E60uXUotHD22Vbe6bSQTAuqWsFaL0fu6SBfYfdH6It5VmulXvHXwfnaYa4dOTGGR16VFc3O5mxFhBM0S
+cFVm5EGk0V/NrSMBqxd8nfgoW+djRVyed9BCPk30fEmOBhggYMTN1DKahHPX5HCitR8Mf7hTe7Dy5LLX
+ODHDDHXQyih66MyDB6hoLoTRm031U9eOmq0BPj7E3ifx9InylIxYzcit6oOnjBfZ5dlDIxf/oySp1is1
+1CGIiw7/8JAppH5cBry2o/l1roG1a2VNmLS+GIVCyBzJpTbsAebWjhBBkzCloNLVd0+uLdXfs+MwfmEf
+92XZdLB1ixEPvFaHmhNnANKY4WYpjDMRs3YEkwLJ2AHlc+rlokbtWzD81nLygSP/ylYfjoLjfXCm8sGO
+4GApEw6gCvBeUYeT8ZvxJzbR/76/TQI0IvjEthSLh2sd58nf0Ex6dhlcojrEZ/eBeB6IsR0FR4IzStG6
+39WAVuB/MqjHN5KotVtyNm8X00ZQ0lx8M141AJgpnfNP8eUutwSkEBzWAGq368qtB60o/sruGJWgMgci
+bp8dn1rcaCeEdlgI6Y+za3YFCzxdrpCItmVybKUylreIyWTANpJCw8KS/7H7NjL6FdhOJpyt2bW1FvD7
+8dRCe6JYqruYWM65dFHd8djaq6GRS/AFuM23q29bi9JBDd5nLyNSBJglgr8tTNbaLnm4k0agBcCCrDhQ
+w5nz/S2CFwtek1+2taU8i5VDPMJx6TdI7NzEAM6KKsx6/zkIhYAZoOLXLvjDUMuFlbc6jTTD1woPVgIr
+u2J+2cRYcjpOhDKW8VFS0u2hYVyBd+7jcpgvNV9TO62HEXam/ey9iFSqnqQsK5DRS/H/fTn9kXlhCJv5
+3zxfS/XN13nr4IxdJqtf+Qq4JQszPhOFGxj8Hs93RdrGjAmfJad5yXsBOCa5vFQshEz2iVDtEwYna/Ev
+pSflHXvzpye96q2Q1r34Pf1BwTG+nYMbswWAKKMthgz6xNCpHDz2W2IYZQvnod6+V6gsaS7eZFSWsp3f
+kEF/h9sbaa7zP3r6C7SdtXv6jQMgnRySw8HuiZ3wmuXrAwvD/BE+dkMqaXffD+Qdf1R3FqIrpyv+6s9a
+//4h21U4pd7DEAWKiAxNVskIfz/4fnF3s7OPJaVQovI/DPlZ3KYfaMN8MRUd0z00ZHrD1fWCrnlcjhbr
+AGM8VPZc8IZtu7rn/YRidf+Ed5qkapPb/+bFpXIOa403kr3T0+ftYXnVCwX3oG9UlswxcUXfSv8hRTze
+UY58KWgGtPQjRLRXkQ1XYTEn3QTOTG5o4B7j85kuz13Ib4HV9iEqemqwMizavpAwrQF3m5JM2a1W8ncC
+2ObK6R2RJpdW6LiGvxnMY6wTAdN7qo5LaONt85Pmt61O9NpQ3EK5OG0W9YT5X87EydBuneMeiJToMEBn
+MZxSKARRnC096DG7qhfF3axvsdcr9dfSbThIy82KEHyRvh2Mng21uAb6KqQOhl++LBmb7Z5CjJWovNfH
+tUvEJDv8JJWViXho6V2VnlRzr5X1zh1qDs6j5M+GvalYaJ1XTVN56/22FDXK4TfelAKalO/HqwwcJ/nG
+ZI0/wYAsKKFt2Smsk9J/o0MvZ2IMPFR9kuVY07kNPx1acmyULYxCaqYUvY4MzcmmAxJ67dbA1UBh43c3
+gF3xdH6kslXIbvs40rIxbsZRJlOUFRn45TRmKoQlEtQVN8j/EytgOZVzXyXFLnnWMBcCvtJuqMXxJRVG
+YcCbqv8pWVX3I+1RlNWj4FNGTGIyH0htQXlOOw94FvrcRg8c+PtHpw1ELRsseB0XzHjzaqXngtGYYqrZ
+/zVYCeSkx5Xk3c4teSN5d+3jNGIsUuPH2+npr5/rH9RPuX3sR6iOknuWRyzJqlwc0Eussz2qwpK8s8fH
+2o2Njcn3poB+4/d43rj6GLTnX9TRBJXvpyW21dFaIJMVVWLwRvNQRuPyOkFOa4U6Zu3OJKQ3vqw4Afr8
+BVvPM+kxZm3EexOjx3qu3Jwe4jWXtirvEwq6S3EL3CQkMXVX7Il7pWBu2CtIQ0Tnw5Hi8J7+oXbyovWG
+w55Qw6uCBMoI8dtpIHfc5RzUqBVwTR7F25Fg6uSbqq/IMtKS17K5h71b2/Tu96Ro8P8Yh/4BVuRRY7on
+9wAtLT4nPxeT6WdTHMDU0ArdpO8W/y8q68kG7A0AAA==
+`,
+	},
+
+	"/assets/images/draughts/128/WM.png": {
+		local:   "assets/images/draughts/128/WM.png",
+		size:    3355,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/3WXd1AT2hKHUyC0EPoFQguhGpCOFCG0UIL0cimCoBJ6J4EoRbogICBNAa8UEQTlKr0Z
+mgJKlyJFQqR3pIkQhcedefNm3pt5Z2Z/Z2bPH7s7c2a/3WQLM0NmRjgjAABgxhphrC7u6H+MHnKh3eib
+/QAAdAGL0bEhFm45OxvZTnO7pIEZGCG0N9bQ9IwMDLRHckUWAbTvaCBzd4IT4yNY2eGPuYUgnL6eHYaR
+IWQoHPu9GHHGmj2lZ1EUp6sTq5v4wRQuP6J6L+p+rWzvtroQNWIuZIfcPhgbnv7Xuy+O4XO/3/4g+5xr
+Qxy7eaLlAbcBPYBUIA9YDuIK7fhvR1E68Jck2jMahkSxWUqx5XzQYyjAHAqx+bGNthA9oUX2kBE9jBbb
+qANyI5SU99tlz0QuiQvpKFJyL3mt7GDuOX+ZDBIuXmKIsRFDSuEq1XHMV3F848CfuHS2UZS1jsNYZfe1
+xvelOl2e4iXCSMdbKGHZuqXvU9J7/qxgniZTt/E2CX3b+6uPRFNiQEVtWOTMbU4nzpkQt77XwvwcQsE0
+JmNRIiXzi0plfn5+42NjeqvDz8qrq2U21taKrF66jSVzSPgGL/bnyV65eJ+uI5HMdsnvSlUCyCOfPjFu
+b20l3UuLDcAcSrD9XjZRNN034kLOuEZhY/D7S9jNlcGCyfHx7Pq2Nl8x5qXkNkvIpwUivQ7fw1QIu5y5
+iyvnyrXt7e3Jp1fDXl1EvU6lUkuqreg+6T6R5NMIbKMd0YXOY7qLS9Q7WkO38+vfvMnwKgsX+w640ZFF
+kRW1JQQwj3wZlntwc/3n3uLk9mzj68aAeca5VRiFWSPWzgzCkh+EL+ai6Fx3zw6Kz8/Pf3W90d9BCB3B
+endIs0OKCNktNIFe3lyy3RIp8TNgMFz7lCM1ubFROeLzDUAWBkvw6c0+9IfDVZI8n7Gdsd50Vb56VerQ
+nqidmxH3IdLQkDk5Uy5nfn4+wLbKtrg1EegW61s4wL59tcVbMlUuVrAkN/cPH3d3g7Wqm0BeYalycLJB
+3ctfQ0NDNoUqd0wzuBRCWvpm6D+H0pZqFRzNEj/Pzl47ODjAb4xf2pPk6hhk+rKzsuI+uEzd5tOowwwO
+DipzBzU7NTj31lSpekWcHiZXu7SbpaSkLLDdByNPnySdsbE8iCcVoYUib8GH+Jea6+rcTE1MpmD5kmT1
+triwTLp945Rh1sXY38ghWEvsi7tgMDhZ6mh9rLxhGJH4NzkrQ2LfgBcLUo+WaVJwrnMW1dLxyciiA0nQ
+plbvSWlGnipd/xMeX2Ph/qg2h9XgTWFir1yaPMCpoD1JVuGUSl3vjKM7XEzf+1FaInRxytfXvWbm5rjS
+HZSUlS3DwhpN7f/oIYIjSXz2IIP2VBylvl65gHTvXOZsoKSsLCExMdETjz/s26/xVldVLTIu0w0JCbko
+rD15zW5PLL1CLvd6KZlMnhpEk58VFtbNzMxMKdAUv3r7Vj68LDAgwLxNEE6k20WyLH3IEaZviy4XdWNC
+SQ0zGeHabTVNwhsaGxNeZX4/PfLZ/Noc4ntGDF0Z4N08+02d7OlJJIrJI8yX0hSaanQuPk0PEM8pp/9q
+hXBpOkRT6GF600HEYfONFKk8GecaQa0o6TNlQpF09sc9kGCnD7+2HXCohBZ7fOJ/ukj8SYr6vdFcFPxj
+a/q94TKKARdH91DlO5RcClJf3B+8bTey0ZjGdPC1gnh+pjm7dOudwGDQV7QKQzTd965tIGA3QxgFiqDh
+8xpgLHmuxbPiPZRdYS0mXRYMK2TillkKTubRiFExo7M8SdgBFdrfMyJkqhlHzTaUyqzftUXzy9/5OREO
+bIvpFU0DqONXxUZvvGssNFcwmTjVFo5nHHELzkl3b5AE/F3IhtmGga7kmBgTrM5dtAy6LKUF48Sbhw1v
+sAihOc+teKI97kyCpkug0OJrf/NaaF6PYUh84mh9+XG6z7r+qwjg979s+vsIl2XyMzbECUrhapFy8Ic1
+MRwhq0gVKmpOh1MLiN+onW3aGMe689x6/IZhtsQWuPvk63t9P/19+pMvTA6lRGjx9TA0OzOWkI3SSPes
+2pnt7XMI3b2lnrM6SkIhUjT04wcqFXQbdzREHR9tSCrjFRO+mG3Ru3eDUiR3NEYG9J8qLPNmYP27hFtR
+IDMEjv35rdRLU+L8mVZAfoH9yhsih9SbveAM5wlLiEDrQYanSWWVTUcirlPDrh7bGn68U6xBsbMbLDWK
+9t2fAeKLQEudJ1h+x5wNVBBePmEmPqsGFb3cL3XjyGnqI2PG6GVFfNEMo/aXi2yMCZtTctZFplMabB4z
+95dtnRGyYlVmTMU+x1R25muEXElBedoZPnHbAv87LC65mydHEDdCP8y5AtDyY/rnqVFlLaZT/R6rsoiZ
+Ij33S/8MZ3LlnBB+J2pe3/blEHsKVyp94dZQ70WLTUkvjjYt9ofCYA/SZ7RzHSaxrBH4qK6XVe/l++wL
+TFGz75pWpNI0I+7QuT0cS9Nd3TkWgw/4rnOHOHBRstOokVuxE1z7MNc1A1r01VKJsTWQvSyH7tuXlOla
+j4XSp9U33qHQ3nQOoQ4qOt9HI8PgkI7cRNUIFHFvQX+K9OqbcL/Mpjg9uuW4LlUV0rGj34t15Gq7e2Qc
+UWe+5dRGtIyYgGUIqFPwHBB0i6yEH0Dg8zE8cBwlHVi4udibvlAVacNkWEugdbsPoyniW5OUBuHnM58D
+if5qj4anToCrB3OBTH9LuQn0qe/rRe9cw8K1fTDiMGAnL6e0fYropvakJRTsafoZoEHlzLF+G3yQG2qT
+gdSejDHmZi2iBj+CsOYk63Ww+dIyTSI/qmcQsrN3rBUBOaK39nmIIj4COG+A+LLshaqbn+xXlk5BihQ8
+k8fmlVH/BDQ2Pg5idlVkEF/SYbq1vwZzoEOBWwFzAeJegPOEetf2eESqPwKRtZNBQGwalR3RX/ewhLpK
+ITlIJB/MwmeBGljEL2ply7zxZ8Bkt+MbF92DSTJZhjRTPIX/C64b2gjUVkE5a0fvfMlSAgyj9T+EXf6z
+g4b1vjmB9lUmYHruLb2tnUEkXqjy3E4Z8GPS92tVeSyJRLIvQMAZarZq/GMppdQcJahrWnjSNa5F31ji
+/lLyzwoBt2ncHE2n4bGv8xPTYnfrtUzTYtTljmt7wp4FeUKsIjxB+4u+yf40fQp6Eqi9LDB+x8FuxTVJ
+jSd47ULhL05G5XJkAOv3FuL4nmnde5EnO+H49Nu3bg+e3blWCfQVDTcHnDMNsCVxyHajj59FpbebQ03Q
+H4TxFER0xSeNamCguu8fIyzz6ZIVbi4osXB4gPS9BSXZY5CmFfaDeh/yXUfz6VKufFtQZuFOD9xb6aIf
+lE2Vs+vxjMJBq1Pl8wy8oryym6+YHfgsBvoVNqtaHaSIDE68zXcRSKm6ra8NCrlT15NnWLC5yESM/CW/
+J6wzwDoqu5CSenYWFa73/BPECKbKo4ZGm42NjdW1tkoJ1mpLXqG5DRIWZ+mqCZTICbLgKPcLdKRbQtd1
+Fv8HITbrGJY8GAxWYfVy5OvXQuL7bwHIU9uIj1JyCs7u0o0GF9DrWrsNzruMIJPaeuVMdR0kFTc488D4
+rpzlkmWNtNM49gYxP0XdcXpQr58CDrGkXu15MC3H++SCjmZ3J4EC8RW/tqUb7oo9DRzyesb2wqBJUNNL
+hNVYn4L7ZRzU/2e0YoLYf2Ab3UxaXnsja0w77gOl9DAj88WQw7D2xsa8ldVVaxbe+/JgmDUSJoQuHeaW
+yGLLpb0NOlxUfZYuMhNEPUS4tsV0I/4H70lsPZUslh4eHr5aOpnsbsyPNxjgmfjpJoPo2doFIjQF1MdD
+WfJq+sTtJeppguihxT6sPgD1cZa0c0dGdduSjFS6eIAQV8u8Rwf0fezBiJbdtYriUW4lfc741h8HTYd8
+f1A0XRXvFzSM8W+vXibQqqXQfKskDLSX+iq95r4Yl35M94iNBbOtngRZVy1eWeEMjg88uLIzbKcZliHW
+wUc8nH7XP2qSqRHh9fzBPAppGDhC+WNbqVCxEsBCEaO8hxlOPOcOfVD4KYrK0EYPlnJ6ntqKr1WCZCco
+WIZyypaK+KitEqy4bX415J2wRRw1mRG8vVFc5ez7GLKkSI9jtwE56qi35pLBpWKtX2lJUekhM2WvXw+S
+7FjsqjrrU3CuTeEJ/XahR12a0k7vEdJM3YRUC9+knvraWpEzZUZKyFlW0hkjX5dtyJSkWjj5GBRle+eF
+pmGilRne0Si7sNR8gp7LQrZSXaPgQbrlXrB3qFmHDK1k+1//zNFOEgJecf4S8J5Kc5ybfhVMdo53s6MD
+qOHZbUask25JrrSeDBQh7j5czqvvY+CWOMdgPJ664MxT1Y5fcBB534adE5CB1ZiPfTzaoRBKAszDYnLj
+NmvBkJKflG9bZJYJCn8qPOPzf5eHfzsI4Apq+TkLQB0Ttaro5Jx4sfQAsPpmmGpd19h/ARhbWPUbDQAA
+`,
+	},
+
+	"/assets/images/draughts/16/BK.png": {
+		local:   "assets/images/draughts/16/BK.png",
+		size:    282,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEaAeX+iVBORw0KGgoAAAANSUhEUgAAABAAAAAQCAYAAAAf8/9hAAAA4UlEQVR4nKyT
+P26DMBTGf60rcQQ6duzAxgkqITF0qQQ34Rgcgit06YbUI3hhyJgxhwhy9FkGOSgZEvyMxeP7/L0/8Hhl
+p70tTmTCfoBv4CNgR+AP+AXOAbu5PgELuDvbhjOrvWzE/8C7MYaqqiiKwhPTNDGOI/M86/EEfAGHbdmK
+7vI8d33fu7Is18zyhYmLKrlqvxVhjPFi3bMsc03T+C0/5kKQdhHrGgTWde0zS2CtdYvJFyZOZ0KAIQ6g
+3l3Xdb5kZd2asPhM0LB7DpK1sPslPv0Zkw1SklFO8jM9vC4DAIu+yW7j6Dm5AAAAAElFTkSuQmCCOyk+
+BhoBAAA=
+`,
+	},
+
+	"/assets/images/draughts/16/BM.png": {
+		local:   "assets/images/draughts/16/BM.png",
+		size:    279,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEXAej+iVBORw0KGgoAAAANSUhEUgAAABAAAAAQCAYAAAAf8/9hAAAA3klEQVR4nKyT
+PW6EMBCFv8S5AylTpqDzCSIhUaRJwa04RK6QJh1SToBoKFJS5hBBXj3kQfxtsYtndrT2e37+mRkeOWlP
+NliYsA/gHXiJ2AB8A1/Af8QO/RXogHAlurhmtoeN+Ad4ds5RFAV5nk9E3/c0TcM4jpr+AW/A7/ba2j1k
+WRbqug7e+/lkjYWJW9xk9fxKhHNuEuvfxBYHXGVi/T4FlmW5Onkb4rQmzqXByjhlW29u2zZCexdneTHN
+6T6wDQbLtvc+QnsXp4pEG5Im8e4yJmukJK2c5GO62S8DAEXyjyb6k2UtAAAAAElFTkSuQmCCOfAu8xcB
+AAA=
+`,
+	},
+
+	"/assets/images/draughts/16/WK.png": {
+		local:   "assets/images/draughts/16/WK.png",
+		size:    282,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEaAeX+iVBORw0KGgoAAAANSUhEUgAAABAAAAAQCAYAAAAf8/9hAAAA4UlEQVR4nKyT
+sQ3CMBBFPyhNVkhFmS6D0FCwR4qMkSEYIA0NSskAKSiyAJ1HSBEko2flJIPSgHPWyV///rfPcNkrMTID
+UcCdJB0lHRbuKekm6SrptXCrq8zz/FHXtR+Gwc/zHBIMRw2Nib+jLIrCjeMYTH3f+7ZtQ4LhqKFZOyTj
+dATOOd80TejAAgxHDc3Sycfzz7TILQjZp2nyXdeFBMc1tHjMzLpwC62yY6iqyksKCYaLNXjMzLpzMu+l
+Z241syUcNTRo8WBMnoPNnpD8Iyb/jcmD9Pco7wxs9TH9HO8BAMr1Z3d6wgl0AAAAAElFTkSuQmCCkfSN
+RBoBAAA=
+`,
+	},
+
+	"/assets/images/draughts/16/WM.png": {
+		local:   "assets/images/draughts/16/WM.png",
+		size:    300,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEsAdP+iVBORw0KGgoAAAANSUhEUgAAABAAAAAQCAYAAAAf8/9hAAAA80lEQVR4nKxT
+sY2DQBCcf5GQExF9SEAIVXzywRdgV0BAGRRhl+DEIqSAQzISDZBdQgdYOmtOtxIgLMs+ZnXyamZ2vQfL
+NzwRSDIDuT8AvwB+HDcAuAK4ALg7bjOSMAxvRVEYpZSZpske5uSo0SPmNZI4jnXf97aormtTVZU9zMlR
+o2erScDuNGitTVmWdgIBc3LU6HGTLK7/zxH5LzTyd425Ri9rWPnlGpyUUodxHBFFEbIsc/Qy2raFePI8
+PwM4itawM+/7CvTQC6BhofceSIOh6zqkaWrHfAZq9NDrdsP/Ie72Gr0X6eNVlj3Y7WN6Ox4DAF/ZUF4F
+SQ2nAAAAAElFTkSuQmCC61V5CCwBAAA=
+`,
+	},
+
+	"/assets/images/draughts/32/BK.png": {
+		local:   "assets/images/draughts/32/BK.png",
+		size:    528,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEQAu/9iVBORw0KGgoAAAANSUhEUgAAACAAAAAgCAYAAABzenr0AAAB10lEQVR4nMxX
+MY7qMBB9/+dLcAAkSjccAYkvcQAOACU3oIAj0HOIHIBDUNBsQUfJSigVDR018upZGTSxBrKgXZyxnnBm
+3ozH9gQ7f5FYkifwTzpPSA/A//LXlboCwCeAj/L3x1sbwALAHoCvwb7ktqMYL8sEwMkYqA6n0vdl4fbk
+RuBnkb+y1XRYG8EqcM4FWLYI62eTMGfearX8bDbz2+3WXy4XfzweA9injjZyLN8y5rfaxHD2o9EoDJbn
+uR8Ohz7LspuNfepoI4dcsUWorYm2VXDz+dwfDgc/GAwqegvkkEsfw36qezsW1swZsNvtVvSPQC597qzE
+IhqzIpX3nPvJJY1nzsJbrVZ+s9kEsB8XI33oa9TEPhrzJj1FCmBRcV+1bjwehwKMhTraNJe+jKF1JXrR
+2KFNFSEkwMpmcckzZ2kNLkKbXgn6MoY8K0yjsUNbKkJIgAF1tXOp64Qc4dOXMeRZYWmdhnKwhOacw/l8
+xvV6FRX6/b5074rm0JcxGCsSZyWQpOkECp1AURTodDrIskxU2O120r0rmkNfxmCsSIpGFmHy1zD5H1Ej
+/oqTH0bJj+NGXEgacSWTJPIHQX/1UtqIa/lbPkz+SKfpn2ZvOQ2TtK8BAL15G4PTkGalAAAAAElFTkSu
+QmCCEbL0DRACAAA=
+`,
+	},
+
+	"/assets/images/draughts/32/BM.png": {
+		local:   "assets/images/draughts/32/BM.png",
+		size:    488,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wHoARf+iVBORw0KGgoAAAANSUhEUgAAACAAAAAgCAYAAABzenr0AAABr0lEQVR4nMxX
+MY7CMBCcg5PgB5Ru+AOReAAfoKSko4An8JO0SDyDhhKJEiR0FQ0dNfJpLBs5Zo/g6MBea4TZ3dndxJvY
+aSGxJC/g200ipA+gsL/K6n4AHAFs7e+/jy6ABYA9AF2DvfXtBjEayxjAWUhUh7PlNhYuTykEjkXZZKlJ
+WAvBKlBKGUi2AOvYIsQr73Q6ejab6c1mo6/Xqz6dTgacU0cbfSSujfnSGAtkPRqNTLKyLPVwONTtdvtu
+45w62uhDX2cLUNsTXanh5vO5PhwOejAYVPQS6ENfcgT7ue7pWEhXzoC9Xq+ifwb6kvPHnVgEOStSec65
+nrylr1x5CHLIFXpiH+S8S99zMmBTcV19XQzIZQzB1g9ymzHxHEwB7Gw2l6+LAbmMIdgmQW4zlp6DKYCP
+l9/tsSCXMQTbUtoN3cZihlIKl8sFt9vNqaKFXMZgrECUVEDykXwJHvbx3W6Hoiga7+PkMoYgR6mAbVjA
+arXCdDp1f6OFXMYQ5CFXFi+iLF7FyTej5NtxFgeSLI5krojySdC3HkqzOJZ/5MPky01y/zR7m7QeVZ8d
+vwMAg1x5WLfzxA8AAAAASUVORK5CYIK1fuUx6AEAAA==
+`,
+	},
+
+	"/assets/images/draughts/32/WK.png": {
+		local:   "assets/images/draughts/32/WK.png",
+		size:    647,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wGHAnj9iVBORw0KGgoAAAANSUhEUgAAACAAAAAgCAYAAABzenr0AAACTklEQVR4nMyX
+sYoiQRCG6w6DhUkNzBTcRzA4MPAlNjBY8BFcMDTZNzAwFdZ8HsDARDBwAzPTA71oDMwMDBT6+Jourxyc
+81YOZ/6mmZ7uqr+rqmu6e75LzsjdgJI2voBnEfkRntXQ90tEforIZ3j+9/IkIm8ishIRd6OuguxTiuNu
+vIhIAnm1WnX9ft9Np1OXJIlT0KaPMWSCIUnQvRssz4dOHMexOx6POmcmkEHWGPJxz1KjEEPQ6XTcfr9X
+/gus12tfrwEddIMR8VeNwGrX7XaVz5fD4eCGw6FrNpsuiiLvJZU2fYwhYwGHicQ/lRf1XEkok8nET4hX
+8/n8Yjlo08cYMshamEjczAkyN4HEhn0wGLh6ve4Wi4V2ZQIZZNFRwBVyIrn1dfD5+CRS4A2ENutvAVl0
+bCTgDFF4+1sUVliq4WU9eU97TuL1ej3XarV8pZ1ORnTQ1ZyAM0RhlZrzDHY4/50rCUmVzgU8IemCN+dK
+n42crj0cCriD/HNqbl9eGWRDUZDZJJcCL69NrpUxGwl04VDAHWRfU3P78s6gXWsIbbYTap0sqyKjQBcO
+BdxB7v3aaegPlkqlwkM2m42Uy2Uplf7sH8vlUpuZsDLowgGX5TaH2IUBuRRrAEeqbLdbHlKr1WS328np
+dNJxaTQa2syElUEXDrgst85ViCS0EeAyIbPZTN+l3W7LaDTSVx+V8XgsURRp1xn0MabeUtCFQ2G4P7VR
+qI2oEFtx7odR7sdxIS4khbiS5X4pLcS1/CE/Jt+0UfRfs4echrmU3wMA9rFBJYeIuNMAAAAASUVORK5C
+YIJuhYyahwIAAA==
+`,
+	},
+
+	"/assets/images/draughts/32/WM.png": {
+		local:   "assets/images/draughts/32/WM.png",
+		size:    618,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wFqApX9iVBORw0KGgoAAAANSUhEUgAAACAAAAAgCAYAAABzenr0AAACMUlEQVR4nMyX
+sY7iMBCG51YUK9FS0IHElVdQItHwEFtQIPEIbE+zb0BBi7QPkAegoEGiID2UK8FVoaCjoADJp8/nQYYN
+m010R/KPojj2zO/xzMROniRn5O5ASRsp8FNEWu5ec32/ReRDREJ3/+fyLCKvIrISEZNwrZzu8w1HZryI
+SAR5rVYzw+HQzGYzE0WRUdCmjzF0nCORs80M0vOuEwdBYE6nk855F+ig6znyniXVGAQQ9Pt9czgclP8K
+m83GXnHABlvnRJDWCbw2g8FA+awcj0czHo9Nu9025XLZrpKLNn2MoeMDDi8S35IXXbmSINPp1E7IqhaL
+xVU6aNPHGDro+vAikVgTVG4EiR/20WhkGo2GWS6X2nUX6KCLjQIuVxNR0tvB62OLSMFqIPSrPgnoYuNH
+Ak4XhdevorDCUw0v+eT5Oyu/BTbYak3A6aKwurcVs8P96vV6Uir9LdjJZCKdTkdardZXBRsr2GALBx1w
+ws0cbq5PwqjdZHQVVDbFlRXYwqGA26WhF5eGNwb9XPN6+dWeFtjCoYDbOfAWlwJ7sFSrVW6y3W6lUqlc
+0pFFsIUDLp/bO8SuHMhFfAc4UmW323GTer0u+/1ezuezjqcGtnDA5XPrXLcOcJ7Ler3WZ2k2mxKG4eU5
+LbCFQ+Fxf8Q5YGeaz+f6LN1u9/IaZRFs4VB43GFhNqLCbcW5H0a5H8eF+CApxCdZ7h+lhfgsf8iPyQ9t
+FP3X7L/h6XPXY+XPABj9CavaUBGwAAAAAElFTkSuQmCCMs9Z9GoCAAA=
+`,
+	},
+
+	"/assets/images/draughts/64/BK.png": {
+		local:   "assets/images/draughts/64/BK.png",
+		size:    1175,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wGXBGj7iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAAEXklEQVR4nOxb
+v0s7SRR/368HBiwimEIwhWDAKwSvsLvqML2Fjb2NnaWFgn+CjV2KFCktLG0OUh1aWByk8EBBwYBXCCoW
+ERaZL5/svpDMvp3ZH/mhmf0sHzKZH++9eTs7Mzsz+5McR+6A3AGOO+A3DowZi0RUIKJy8L9NRB9E9H/w
+f6ocUCGiKhH9SUR/BP8LWh4GnHBHRP8S0T9E9Hfw/9tduLNHRHRLRCojbwNZ5UEVX/NaI6IGEXlCRbLS
+C2SvaTq/BEpEVBtRxXV6ga6SZsPEsENEL4Kho+ZLoHtiQEdWFwwbN+uGTnVkwDB2KRgzKV4GNo0FUHQj
+GDFp3qRxwg8OxAQUNInod45Ig2KxSOVymUolvx97fn6mdrtNb29vnCUt/iOiv0Y1oSqkbfYLCwtqd3dX
+nZ2dqcfHRxUFpCEP8qKMJCsGL0fVJyTu8FZWVlS9XledTofr2L3u7+9Vs9lU5+fnXSKMuH6gDMpChiTb
+wrpme2bsCEoiOTc3p05OTpTneb3KNBoNtb29rYrFolgGcUhDHuRlp0EGZEGmVMbAoQ2RpSTj/Pr6eu9u
+vr+/q+PjY2Olo4gyKAsZ3GogW8obwZdhTZZqgnCRW1tbPYMvLi7U0tKSmC8JIQOy2KHQIeWLYE2rS2Ks
+xZ3ewjBu8rhzUp4shEx+JBI4wcv67tAQhIaIpsl3fn9/X8wzDEI2t4QEj0NDq1NslOPcfXRO/MyP4s5H
+tQTojNkxemlfpY8EYSGih+ZnXkrXOTMzozY3N9XBwYGq1WpdIow4pElldHKfAN1SusAjrW6xYF3MwBiN
+ZxJN0tbhzc7OqsPDQ/X09MRDfQhIQx7klWQwoQs6oTvmPOFWq5sVFUFIiJioxGn6q6urqtVqcT2tQF6U
+kWTpjwJskNIFVrQ6GrEnCBggpqqYrICmcR4VMd31KKCMyQnQyfpjTpv3tDpm6/0xX4ehmLVJ6dzsk9x5
+HShrehygG/lgi5SeZTRoCQIGiJcWKMfUVUoH8TxnBWRIskHoRh7YIqVrbGl1NKIjCBggv9VFNX/06Gma
+vg7IiBodoJvfIqV0jR2tjpFYFAqLijEWS+kghrVhAbIkHSDPQUz9UB8X42yNWd+lsZiBwMPDA0eFsLGx
+wcHMMMliG9gmCwpxHGCVxCs5r6+vHBVCpZJo1DFeJllsA9tkQTmOA5y6JAe0bQ7AGh4C8/PzHBXC3d3w
+tvRMstgGtsmCdhwHfNgcgAVMBJaXlzkqhOvraw5mhkkW28A2WWCtm3PDoNQCKM6W9NXVVfe3Wq32R/eu
+z89POj095b+pARmQJYF1sy0WWOuUT4X7psLOvww5/zrs/IKI40ti/pKY84uizi+LO74x4m+MOL815vjm
+qL856vz2uOMHJPwDElN7RCY/JJXgkNRUHpPLD0qmOCjp+FFZ/6is44el/cPS+XH54Li84x9M+B9MfNtP
+Zn6kKzY9H02NwwFf+rM556+f5uTcAbkDpt0BvwYAy0H3uPNP8VkAAAAASUVORK5CYII75Ft5lwQAAA==
+`,
+	},
+
+	"/assets/images/draughts/64/BM.png": {
+		local:   "assets/images/draughts/64/BM.png",
+		size:    1051,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEbBOT7iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAAD4klEQVR4nOxb
+PUsDSRh+lCsCFhFMIZhC0OIKQXurgxR2Cjb2Nnb+AcGf4A+wSJFSQTstDlIdWtp5oKBgiisEFYsIW8zx
+ZPcNur6bmd0ku5rZZ3nIZj7er52dnZmdnYbnKANQBsDzAPwhJzljHkAFQD363wHwAeC/6P9EBWAZQAPA
+OoC16H8lVkbAINwDuAHwD4C/o/+/7uCVPQBwB8AMybtIVv2rip95rABoAQgUR4ZlEMleien8EagBOB6T
+43EGka5azIbCsAPgRTF03HyJdBcGdmRNxbC82RzQqY4NfIxdKcYUxavIplxARbeKEUXzNksQpuTEEVTQ
+BvCnJGRBtVpFvV5HrRb2Y8/Pz+h0Onh7e5MiWfEvgL/GNaCqZG32c3NzZnd315ycnJinpyeTBOaxDMuy
+jibLgVfj6hNSd3hLS0um2WyabrcrPvaOh4cH0263zdnZWY88Z9pnsA7rUoYm28JmzPahsaMoSeTMzIw5
+OjoyQRD0nWm1WmZ7e9tUq1W1DtOYxzIsK0GjDMqiTK3OAI7sEVlL85xfXV3tX83393dzeHg40Okksg7r
+Uoa0GsrWyibwZVSDpWNFuMrNzc2+wRcXF2ZhYUEtl4aUQVkSUOrQyiXwOOZLaqy4Dm9pmDR5XjmtzDCk
+TLklUgQhGHbu0FKEfiObplz5/f19tcwoSNnSElLcDq2YT86ou1x9dk5yz4/jyie1BOp07BiDrFPpA0XY
+N7KHlnteyx8HpU+gbi1f4UHMNydYFzP4jOY9ySY5ig7PldRFndTtOE64i/lmxbIi5Bs5UMmr6SfdCrRB
+y1e4HPNxIPYUAV/IoSoHK2SW5/ywpE7R7zhs3ov5OHBZfN22LL61tYVKpYLT09NRTGJSgzqpmzbQFges
+pwnAmi0AGxsbvd/z83NJyh2iW2yxwOrT56OrNKEvlFldEc1fSN0yi9TyY+zGfEzEvFJZVcxnsZafJ2UM
+4ngh5l1uAetcmosZPHl8fJSkwiA2iE0WVFwCYJUkKzmvr6+SVBjEBrHJgrpLALw6tAB0bAHgGh5PZmdn
+JakwiA1ikwUdlwB82ALABUyeLC4uSlJhEBvEJgusvnn3GNRaAFxeSV9fX/d+G43G5+RcD9EttlhwnyYA
+N7YAXF5e9ofEkpY3RLfYYoHVJy8nQ+V0OGE67P2CiOdLYuGSmPeLot4vi3v+YiR8MeL9qzHPX46GL0e9
+fz3u+QaJcIPExG6RKTdJpdgkNZHb5MqNkhk2Snq+VTbcKuv5Zulws3S5XT7aLu/5BxPhBxO/9pOZqWzV
+JuejqTwC8KM/m/P+mB6cXQagDMCkB+D/AQCOHSVgQkJdzAAAAABJRU5ErkJggsjP+A4bBAAA
+`,
+	},
+
+	"/assets/images/draughts/64/WK.png": {
+		local:   "assets/images/draughts/64/WK.png",
+		size:    1422,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wGOBXH6iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAAFVUlEQVR4nOxb
+v0sjWxT+dlcwYCMoGEhAC7uk2MJC2EKLB9opaLFg4QObLQQbm4eChYWN4B9g4QNLCy0FBW0er1BwQcUi
+goIKikIEC5U8uI/PzAk317kzk0zirt58wyUzd+ae851zf//IZziOhgMaDnDcAU1y88ZIAkgASHvPlwCe
+AFx7zx/KAd0A/gDwDcBX7zlhfCOgE04B/ATwD4Bt7/ndXczZWQA5ACpmyHmy0uUqfs8rC2AVQMHHkLih
+4MnOGjpj4ZPcxEQ7gAUAf/pVq1Qqhf7+fvT09CCbzaK7uxvJZBKJRLEmPD094fr6Gqenpzg6OsL+/j52
+d3dxdXUlInT8B+BvAH8BuJPIX4nvAPJaTjG3VFtbm5qamlJ7e3uqWjAtZVCWLtsLeU/3LwOzb0Uj9GJ4
+R0eHWlpaUo+Pj2JHbFAWZVK2rssLKwGNat3AbuxfjYT68uWLmpmZqanh5kXZ1EFdum6PS9LgWDdQ0Ymm
+XGUyGXVwcCA86w7qok6dg8cp+ebGDw0NqYeHB+EWCfl8Xh0eHqqdnZ2XwHvGVQLqpO63dELCLPZsoKLg
+9vZWLS8vq5GREZVKpUrpzcB3/IbfMk0UkINPdahLm7BSqfG5XE6Nj4+r5ubmUjr+dnZ2qr6+vpccZOA9
+4/RvmIZpKaMKJ6wY3GPjuyb8hbgo9wOLJ0lJY0VjxsbG1NraWmBR5zt+w2/FaZRBWWHVzKc61KyLbNf7
+eTY+QWTYQElutrS0qLm5uYrrtziDaSlDSk1QQ0tORsOY97jHxrIIZW4EkVhfXy8RHhgYUBcXF/KqalAG
+ZYlDqcMGcjO6yGXDloqR1cf17INtIDFRzpyrNShTMiHICeRozB9izR1WRRhHYbZBDj0vOc8RW71A2VIS
+bCWRHI0R46phU2Sk9dy3Gca6J3W+HjlvKwnUaWuLxFFeKFQ7lZ4VIZyM2HJfuiDW0ygoFApqa2tLLSws
+qImJiZfAe8bxXRRIm2DrisnVmEDNGrZFQi6sz2cfzTrJIhnW4JHU/Py8bUJTqmb8xuZsAXVRJ3XbxgnG
+2CBn2BaKbi2xdUrLgUqUon9ycuI3drcGfss0UaoCOfiBnHWZnk2Rrx+SkMNTP3CoysEKQ1A/T0OCct0W
+mCbICdQp+m3DZmPY/cOwMXBZ/Jssi3Mlxw8bGxt4fn7G6OgoWltbJboMXOnh+5ubG4mKDKZhWsrwA3Xy
+PTmQix8M7iWbojjgqziAy1h+2Nzc5A+Gh4cl6hUWFxdxfHwsjxWDaSnDBtEtXEwY3Es2RbkepeiwdQ4q
+Xrbizxa9mqJvBsqw9Q7UHVRNyV2T9WjYaEVSS6TOzs5E3ivF7IttMJTHCrZM4CVjEL+MIHddjt9agV8V
+KJtLc/XWxOUlN3KArq4uiXoFruzWCkGyhINwCuGeiOKAslGTLF3ruLu7KzVENnCJu1YIkiUchFMI93QU
+Bzh1+TmgrCz5dUPt7cVp9v39vUS9Ajc/aoUgWcJBOIVwv4zigLJU3LExkU4XS9L5+blEhXVBsRAkSzgI
+pxDurzzifDfoVwKgb0lzr84Pvb29/MH29rbxxtt3b2rC5OSkPFYNymhq8t/FF93CxYTB/bQSB/wM64IG
+BwdLQ2KJMzE9PY1MJiOPFYNpKcMG0S1cQrrPkk2NyZA2GWpMhy3TYecXRBxfEisuiTm/KOr8srjjGyPF
+jRHnt8Yc3xwtbo46vz3u+AGJ4gEJx4/IFI/IOH9IyuoEl47JOX5QsnhQ0vGjssWjsh/msHTjuHwNjsu/
+6z9MOP+XmU+1Fff+/jT1Fg74rf825/z1Ofh1wwENB3x0B/w/ADcg/TZCBxXrAAAAAElFTkSuQmCCt+K+
+PY4FAAA=
+`,
+	},
+
+	"/assets/images/draughts/64/WM.png": {
+		local:   "assets/images/draughts/64/WM.png",
+		size:    1301,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/wEVBer6iVBORw0KGgoAAAANSUhEUgAAAEAAAABACAYAAACqaXHeAAAE3ElEQVR4nOxb
+v0srWRT+3nPBgI2gYCABLdKZ4hUWwiu0WNBOQYsHFm75OutFwXJL/4AULlhaaCkoaLNsoeADhS0iqKgQ
+UIhgYcSFu3zOnHBz985kkklmnt58l0uSO3PP+c65v3/kMxxHzwE9BzjugF/kS8LIAsgAyPu/bwHUAFT8
+3x/KAQUAvwL4CuCL/ztjvCOgEy4A/ADwF4AD//e7CyzZNQBlACpmLPuy8o0qfs5QBLAF4NViSNz46ssu
+Gjpj4ZN8iYlhAH8A+M3WrHK5HKanpzExMYFisYhCoYBsNotMxmsJtVoNlUoFFxcXOD8/x8nJCY6OjnB3
+dycidPwL4E8AvwN4kMQ08Q1AVSsplpYaGhpSKysr6vj4WLUL5qUMytJl+7Hq604NLL5NjdCb4SMjI2pj
+Y0M9Pz+LHbFBWZRJ2bouP26GdKpdA4exvzUSqq+vT62urnbUcDNQNnVQl67b55I1OHYNVPSPplyNj4+r
+09NT4dl1UBd16hx8TtnEjZ+bm1NPT0/CLRKq1ao6OztTh4eHb5HfmdYKqJO6k3RCxqz27KCi4P7+XpVK
+JbWwsKByuVw9vxn5jO/wXeaJAnKwNIeu9AmbrRpfLpfV8vKy6u/vr+fj5+joqJqamnorQUZ+Z5r+DvMw
+L2W04YRNg3tsfNOEvxEX5TawepKUdFY0ZmlpSW1vb4dWdT7jO3xXnEYZlNWsmVmaQ8eGyGF9nGfnE0aG
+HZSU5sDAgFpfX2+5fYszmJcypNaEdbTkZHSMVZ97bJREKEsjjMTOzk6d8MzMjLq5uZFHbYMyKEscSh1B
+IDdjiCwZtrSMoj6v5xgcBBIT5Sy5ToMypRDCnECOxvoh1tphS4RxFhY0yaHnpeQ5Y+sWKFtqQlBNJEdj
+xrhl2BQZeb30gwxj25M2342SD6oJ1BnUF4mj/Pja7lJ6TYRwMRJU+jIEsZ0mBekTgoZicjUWUGuGbZFQ
+bjbmc4xmm2SV7ESHFxXURZ3UHTRPMOYGZcO2pihomQOXtJyoJFX1g5oCOdhAzroNvk2Rw3fJyOmpDZyq
+crLC2M44HxfUKfqDps3GtPu7YWPotvhX2RbnTo4Nu7u7eHl5weLiIgYHByU5MVAndZMDudhgcK/bFMUB
+X8QB3MayYW9vjx+Yn5+XpMQhuoWLCYN73aYo4Vmqzv7+vtQoa/VKo/oLqDusmZK72OHbFClktUzq8vJS
+5P1PMcfitCFzEFtBkLtui22vwNYEGtbS3L01cXvLgxxgbGxMklKDcBBOTbhnojigYdYkW9c6Hh4e6h1R
+2hAOwqkJ93wUBzgVbA5oqEs8tDAxPOwtsx8fHyUpNQgH4dSE+20UBzTk4omNiXzeq0lXV1eSlBqEg3Bq
+wr0WxQEV/UUeV5lgu+Nx1/X1daq1gLrJgVxs/ZHB3Xr8bnMA9CNpntXZMDk5yQ8cHBwYT5KD6BYuJgzu
+Dd5o5oAf4gAeVNowOztbnxJLWtIQ3cLFhMG9blNvMaQthnrL4YDlsPMbIo5viXlbYs5vijq/Le74wYh3
+MOL80Zjjh6Pe4ajzx+OOX5DwLkg4fkXGuyLj/CWpQCe4dE3O8YuS3kVJx6/KeldlP8xl6d51+Q5cl3/X
+f5hw/i8znzor7v39aSoJB/zUf5tzPnwOf9xzQM8BH90B/w0ArRW1s+0JrJEAAAAASUVORK5CYILylESp
+FQUAAA==
+`,
+	},
+
+	"/assets/images/draughts/README": {
+		local:   "assets/images/draughts/README",
+		size:    568,
+		modtime: 1792224064,
+		compressed: `
+H4sIAAAAAAAC/22SQU/DMAyF7/kVVs9bucNtAnFAQ5MYTBy9xGuits6I01Xj1+O269hhOUSV8/X5+SVb
+HwRcSGRzTGewkTMGFggtViQQD+ASdpXPAsdAlmQBmCF7AsFWt/BLYlDGivUkN1gTuYI+ZD8chgQSu2QJ
+Pr5e4RAakhKK3boAZAfFal0YTDTK7HzINJZXDdoaWuLFRO3eZlw/BrQOXElpzCc3oaY7JrQiBB5PepiQ
+5ahNOMNedasUO3bKSFQMs1FWA0CGPQ1D9wyRgdQ/Jc2lUfdDHPLTqcbT2Mthqi8FAd3M9Ns8M7ysN9vv
+5znLQ4rtjUWhrM63/iYR6CnNvfdntc2uhIHYvM/ExV+Ljq6CrQn8fyU9nuHuhahi6pg1sgm2KRzzLBLE
+XF/BozGgqywfbCLMtJwGWA7oUk5VKd78AasqrVk4AgAA
+`,
+	},
+
+	"/assets/images/draughts/SVG/BK.svg": {
+		local:   "assets/images/draughts/SVG/BK.svg",
+		size:    391,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/4WQ30vDMBDH3/tXnOfzco11CNJ2sHYMQXTgVHwsabYGYzLS0Mz/3rQyEX3YwXFwd9/P
+/cgXxw8Ng3S9sqZAzlKE3jembbQ1skBjcVEm+UX9WG3fNivohz1snpf3dxXgjOg1q4jqbQ1PL2vgjBOt
+HhCw8/5wSxRCYCFj1u1p7ZpDp0RPsZHGxiiiCOOctb5FiDNG9KBkWNpjgSmkwNPJsUwAcqGc0BJErM3j
+juLzO7oCr2PYKa0LvEwnGy9w9l3+S8yCan0XhQh0Bnp1c4Ka+IdfxN1kf4nZeSKf/6x5YkRJPj6hTL4A
+T/1Wg4cBAAA=
+`,
+	},
+
+	"/assets/images/draughts/SVG/BM.svg": {
+		local:   "assets/images/draughts/SVG/BM.svg",
+		size:    340,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/4WQ30vDMBDH3/tXnOfzcolVBGk6WDuGIDpwKj6WNFuDNRlpaOZ/b1oRRB88OA7ux+fu
+e8Xy9N7DqP1gnJUoGEcYQmPbpndWS7QOl2VWnNUP1e51u4ZhPMD2aXV3WwEuiF7yiqje1fD4vAHBBNH6
+HgG7EI43RDFGFnPm/IE2vjl2Rg2UGmlqTEOUYEKwNrQIaceEHo2OK3eSyIGD4LNjmQEUynjVa1CpdpVu
+VB9f0Uu8TGFv+l7iOZ9tUuDdm/6TWETThi4NItA/0Ivrb6hNf/hB3M/2m5jPxGJSVGafTJ/FplQBAAA=
+`,
+	},
+
+	"/assets/images/draughts/SVG/WK.svg": {
+		local:   "assets/images/draughts/SVG/WK.svg",
+		size:    391,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/4WQUWuDQAzH3/0UWfpc482VwfAsVEsZjK2wbmOPorYevXrlPLzu2y863KuBELjk/8v/
+kqxvFw19bTtlWokijBA6V7RVoU1bS2wNrtMgucvfssP3fgtdf4L9x+blOQNcEn3FGVF+yOH9cwciFETb
+VwRsnLs+EXnvQx+Hxp5oZ4tro8qOeJCGQRYRw4QIK1ch8I4B3avab8xNYgQRiGhMTAOApFS21DWU3Fux
+x/Lnr1qJD1yOSmuJi+MYww+sObP7RTTG9LD0qnINCxFoBnr/OEFbvsMsMZ4nitW/zYnBkmQ4Qhr8AihL
+Y2uHAQAA
+`,
+	},
+
+	"/assets/images/draughts/SVG/WM.svg": {
+		local:   "assets/images/draughts/SVG/WM.svg",
+		size:    340,
+		modtime: 1792223995,
+		compressed: `
+H4sIAAAAAAAC/4WQ30vDMBDH3/tXnLfn5RKrCNJ0sHYMQXTgVHwsabcGYzLS0Mz/3rSy5x0cB/fjc/e9
+YnX+MTB2ftDOShSMIwyhsW1jnO0kWoerMitu6tdq/7XbwDAeYfe+fn6qAJdEn3lFVO9rePvYgmCCaPOC
+gH0Ip0eiGCOLOXP+SFvfnHqtBkqNNDWmIUowIVgbWoS0Y0KPuotrd5bIgYPgs2OZARRKe2U6UKl2n25U
+v//RS7xL4aCNkbg4zDYp8O47Xb/gs10Sy6jb0KdBBLoCvX24QG36w1ViPhOLSVGZ/QGLcv4ZVAEAAA==
+`,
+	},
+
 	"/": {
 		isDir: true,
 		local: "/",
@@ -5677,4 +6230,28 @@ mMVPm+vTzD1Lr0buKZIirpU/Zu5vokl0z98AAAD//zq3hZCPAgAA
 		isDir: true,
 		local: "/assets/images/SVG",
 	},
+	"/assets/images/draughts": {
+		isDir: true,
+		local: "/assets/images/draughts",
+	},
+	"/assets/images/draughts/128": {
+		isDir: true,
+		local: "/assets/images/draughts/128",
+	},
+	"/assets/images/draughts/16": {
+		isDir: true,
+		local: "/assets/images/draughts/16",
+	},
+	"/assets/images/draughts/32": {
+		isDir: true,
+		local: "/assets/images/draughts/32",
+	},
+	"/assets/images/draughts/64": {
+		isDir: true,
+		local: "/assets/images/draughts/64",
+	},
+	"/assets/images/draughts/SVG": {
+		isDir: true,
+		local: "/assets/images/draughts/SVG",
+	},
 }
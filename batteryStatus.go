@@ -0,0 +1,81 @@
+package godgt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// DefaultLowBattery is the battery level, in percent, at or below
+// which a BatteryStatus is marked as low.
+const DefaultLowBattery = 20
+
+// BatteryStatus is the state of a Bluetooth board's battery.
+type BatteryStatus struct {
+	// The capacity left, in percent.
+	Capacity int
+
+	// How long the battery will last, or (if it's charging) how
+	// long until it's full. Not all boards know.
+	TimeLeft    time.Duration
+	HasTimeLeft bool
+
+	// How long the board has been switched on, and in standby.
+	OnTime      time.Duration
+	StandbyTime time.Duration
+
+	Charging    bool
+	Discharging bool
+
+	// Set if the battery is running down, and Capacity is at or
+	// below the board's LowBattery level. Only boards that send the
+	// charging status can say so.
+	Low bool
+}
+
+func (bs *BatteryStatus) ToString() string {
+	status := fmt.Sprintf("Battery: %d%%", bs.Capacity)
+	if bs.Charging {
+		status += ", charging"
+	}
+	if bs.HasTimeLeft {
+		status += fmt.Sprintf(", %s left", bs.TimeLeft)
+	}
+	if bs.Low {
+		status += " (LOW)"
+	}
+	return status
+}
+
+// ClockText is a short form of the status, for the clock's display.
+func (bs *BatteryStatus) ClockText() string {
+	return fmt.Sprintf("bat %d%%", bs.Capacity)
+}
+
+// RequestBatteryStatus asks the board for a BatteryStatus message.
+// Only Bluetooth boards have a battery; others don't answer.
+func (dgtboard *DgtBoard) RequestBatteryStatus() error {
+	_, err := dgtboard.WriteCommand(DGT_SEND_BATTERY_STATUS)
+	return err
+}
+
+// PollBattery asks the board for its battery status every interval,
+// until the context is done. The answers come back as BatteryStatus
+// messages, which are marked (and logged) as low once the battery has
+// run down to LowBattery.
+func (dgtboard *DgtBoard) PollBattery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		err := dgtboard.RequestBatteryStatus()
+		if err != nil {
+			log.Println("Battery: " + err.Error())
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
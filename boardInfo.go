@@ -0,0 +1,128 @@
+package godgt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ERR_NO_BOARD_INFO = errors.New("Board didn't say anything about itself")
+
+// BoardInfo is everything the board will say about itself. Fields the
+// board didn't answer about are left empty.
+type BoardInfo struct {
+	SerialNumber     string
+	LongSerialNumber string
+
+	BusAddress    int
+	HasBusAddress bool
+
+	// The firmware version, e.g. "1.02".
+	Version      string
+	VersionMajor int
+	VersionMinor int
+
+	Trademark string
+}
+
+// Update fills in the field that an InfoUpdate is about.
+func (bi *BoardInfo) Update(infoUpdate *InfoUpdate) {
+	switch infoUpdate.Kind {
+	case InfoSerialNumber:
+		bi.SerialNumber = infoUpdate.Text
+	case InfoLongSerialNumber:
+		bi.LongSerialNumber = infoUpdate.Text
+	case InfoBusAddress:
+		bi.BusAddress = infoUpdate.Number
+		bi.HasBusAddress = true
+	case InfoVersion:
+		bi.Version = infoUpdate.Text
+		bi.VersionMajor = infoUpdate.Number
+		bi.VersionMinor = infoUpdate.Minor
+	case InfoTrademark:
+		bi.Trademark = infoUpdate.Text
+	}
+}
+
+// Serial returns the most specific serial number the board gave, for
+// telling boards apart.
+func (bi *BoardInfo) Serial() string {
+	if bi.LongSerialNumber != "" {
+		return bi.LongSerialNumber
+	}
+	return bi.SerialNumber
+}
+
+func (bi *BoardInfo) ToString() string {
+	var parts []string
+	if serial := bi.Serial(); serial != "" {
+		parts = append(parts, "serial "+serial)
+	}
+	if bi.HasBusAddress {
+		parts = append(parts, fmt.Sprintf("bus address 0x%04x", bi.BusAddress))
+	}
+	if bi.Version != "" {
+		parts = append(parts, "version "+bi.Version)
+	}
+	if bi.Trademark != "" {
+		// The trademark runs over several lines.
+		parts = append(parts, strings.Join(strings.Fields(bi.Trademark), " "))
+	}
+	return "Board: " + strings.Join(parts, ", ")
+}
+
+// identifyCommands are the commands whose answers make up a BoardInfo.
+var identifyCommands = []byte{
+	DGT_RETURN_SERIALNR,
+	DGT_RETURN_LONG_SERIALNR,
+	DGT_RETURN_BUSADRES,
+	DGT_SEND_VERSION,
+	DGT_SEND_TRADEMARK,
+}
+
+// Identify asks the board about itself, and collects the answers until
+// it has all of them, or the context is done. Older boards don't know
+// about some of the commands, and never answer them, so it's normal
+// for the context to run out; whatever was answered by then is
+// returned. It's only an error if the board didn't answer at all.
+//
+// The answers are also passed on as InfoUpdate messages, so something
+// has to be running ReadLoop.
+func (dgtboard *DgtBoard) Identify(ctx context.Context) (*BoardInfo, error) {
+	dgtboard.identifyMutex.Lock()
+	defer dgtboard.identifyMutex.Unlock()
+
+	// Throw away anything left over from earlier.
+	for {
+		select {
+		case <-dgtboard.infoUpdates:
+			continue
+		default:
+		}
+		break
+	}
+
+	for _, command := range identifyCommands {
+		_, err := dgtboard.WriteCommand(command)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	info := &BoardInfo{}
+	answered := make(map[InfoKind]bool)
+	for len(answered) < len(identifyCommands) {
+		select {
+		case infoUpdate := <-dgtboard.infoUpdates:
+			info.Update(infoUpdate)
+			answered[infoUpdate.Kind] = true
+		case <-ctx.Done():
+			if len(answered) == 0 {
+				return nil, ERR_NO_BOARD_INFO
+			}
+			return info, nil
+		}
+	}
+	return info, nil
+}
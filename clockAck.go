@@ -0,0 +1,120 @@
+package godgt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Values of ClockAck.Ack1 for acks the clock generates by itself,
+// rather than in response to a command.
+const (
+	CLOCK_ACK_READY          = 0x81
+	CLOCK_ACK_BUTTON         = 0x88
+	CLOCK_ACK_MODE_23        = 0x8a
+	CLOCK_ACK_NOT_IN_MODE_23 = 0x90
+)
+
+// ClockAck is an acknowledgement from the clock, reconstructed from
+// the bytes of a DGT_BWTIME message as described in dgtconstants.go.
+type ClockAck struct {
+	Ack0 byte
+	Ack1 byte
+	Ack2 byte
+	Ack3 byte
+}
+
+func NewClockAck(ack0, ack1, ack2, ack3 byte) *ClockAck {
+	return &ClockAck{
+		Ack0: ack0,
+		Ack1: ack1,
+		Ack2: ack2,
+		Ack3: ack3,
+	}
+}
+
+// IsError is true if the clock couldn't carry out a command (for
+// example, a SetNRun while the clock is not in mode 23).
+func (ca *ClockAck) IsError() bool {
+	return ca.Ack0&0x40 == 0x40
+}
+
+// IsAutoGenerated is true if the clock sent the ack by itself (for
+// example, when a button is pressed), rather than in response to a
+// command.
+func (ca *ClockAck) IsAutoGenerated() bool {
+	return ca.Ack1&0x80 == 0x80
+}
+
+// Command returns the clock command (DGT_CMD_CLOCK_*) being
+// acknowledged. It's only meaningful if the ack isn't auto-generated.
+func (ca *ClockAck) Command() byte {
+	return ca.Ack1
+}
+
+func (ca *ClockAck) ToString() string {
+	kind := "response"
+	if ca.IsAutoGenerated() {
+		kind = "auto"
+	}
+	if ca.IsError() {
+		kind += ", error"
+	}
+	return fmt.Sprintf("Clock ack (%s): %02x %02x %02x %02x", kind,
+		ca.Ack0, ca.Ack1, ca.Ack2, ca.Ack3)
+}
+
+// ClockButton identifies a button on the clock.
+type ClockButton byte
+
+// The buttons, as reported in Ack3 of a CLOCK_ACK_BUTTON ack.
+const (
+	ClockButtonBack  ClockButton = 0x31
+	ClockButtonPlus  ClockButton = 0x32
+	ClockButtonRun   ClockButton = 0x33
+	ClockButtonMinus ClockButton = 0x34
+	ClockButtonOK    ClockButton = 0x35
+)
+
+func (cb ClockButton) String() string {
+	switch cb {
+	case ClockButtonBack:
+		return "Back"
+	case ClockButtonPlus:
+		return "Plus"
+	case ClockButtonRun:
+		return "Run"
+	case ClockButtonMinus:
+		return "Minus"
+	case ClockButtonOK:
+		return "OK"
+	default:
+		return fmt.Sprintf("Unknown (0x%02x)", byte(cb))
+	}
+}
+
+// ParseClockButton returns the button with the given name (in any
+// case), as returned by String.
+func ParseClockButton(name string) (ClockButton, error) {
+	for _, button := range []ClockButton{ClockButtonBack, ClockButtonPlus,
+		ClockButtonRun, ClockButtonMinus, ClockButtonOK} {
+		if strings.EqualFold(name, button.String()) {
+			return button, nil
+		}
+	}
+	return 0, fmt.Errorf("Unknown clock button: %s", name)
+}
+
+// ClockButtonPress reports a button being pressed on the clock.
+type ClockButtonPress struct {
+	Button ClockButton
+}
+
+func NewClockButtonPress(button ClockButton) *ClockButtonPress {
+	return &ClockButtonPress{
+		Button: button,
+	}
+}
+
+func (cbp *ClockButtonPress) ToString() string {
+	return "Clock button: " + cbp.Button.String()
+}
@@ -0,0 +1,75 @@
+package godgt
+
+import (
+	"errors"
+	"time"
+)
+
+var ERR_CLOCK_ACK_TIMEOUT = errors.New("Timed out waiting for clock ack")
+var ERR_CLOCK_ACK_ERROR = errors.New("Clock could not carry out command")
+
+// ClockAckTimeout is how long SendClockCommand waits for an ack. The
+// documentation says that the ack usually arrives within one or two
+// seconds.
+var ClockAckTimeout = 3 * time.Second
+
+// encodeClockCommand wraps a clock command (one of DGT_CMD_CLOCK_*)
+// and its content in a DGT_CLOCK_MESSAGE.
+func encodeClockCommand(command byte, content []byte) []byte {
+	// The size counts everything after the size byte itself: the
+	// start marker, the command, the content and the end marker.
+	bytes := []byte{
+		DGT_CLOCK_MESSAGE,
+		byte(len(content) + 3),
+		DGT_CMD_CLOCK_START_MESSAGE,
+		command,
+	}
+	bytes = append(bytes, content...)
+	return append(bytes, DGT_CMD_CLOCK_END_MESSAGE)
+}
+
+// SendClockCommand sends a command to the clock, and waits for it to
+// be acknowledged. Only one command is sent at a time; concurrent
+// callers queue up behind each other, since the clock can't cope
+// with a new command before the previous one has been acked.
+//
+// Acks are only sent when the board is in UPDATE or UPDATE_NICE mode
+// (see DGT_SEND_UPDATE_NICE), and only arrive if ReadLoop is running.
+// If no ack arrives within the timeout, ERR_CLOCK_ACK_TIMEOUT is
+// returned; if the clock reports an error, the ack is returned along
+// with ERR_CLOCK_ACK_ERROR.
+func (dgtboard *DgtBoard) SendClockCommand(command byte, content []byte, timeout time.Duration) (*ClockAck, error) {
+	dgtboard.clockMutex.Lock()
+	defer dgtboard.clockMutex.Unlock()
+
+	// Throw away any ack left over from a command that timed out.
+	for drained := false; !drained; {
+		select {
+		case <-dgtboard.clockAcks:
+		default:
+			drained = true
+		}
+	}
+
+	_, err := dgtboard.WriteBytes(encodeClockCommand(command, content))
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.After(timeout)
+	for {
+		select {
+		case ack := <-dgtboard.clockAcks:
+			if ack.Command() != command {
+				// A late ack for some earlier command.
+				continue
+			}
+			if ack.IsError() {
+				return ack, ERR_CLOCK_ACK_ERROR
+			}
+			return ack, nil
+		case <-deadline:
+			return nil, ERR_CLOCK_ACK_TIMEOUT
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package godgt
+
+import (
+	"log"
+	"time"
+)
+
+// High level wrappers around SendClockCommand, one for each of the
+// DGT_CMD_CLOCK_* commands. See dgtconstants.go for the details of
+// each message. All of them wait for the clock to acknowledge the
+// command, and so need the board to be in UPDATE or UPDATE_NICE mode.
+
+// ShowText shows up to eight characters of text on a DGT3000 clock.
+// Longer text is truncated. Characters outside printable ASCII are
+// shown as spaces.
+func (dgtboard *DgtBoard) ShowText(text string, beep bool) (*ClockAck, error) {
+	content := make([]byte, 9)
+	for i := 0; i < 8; i++ {
+		content[i] = ' '
+		if i < len(text) && text[i] >= ' ' && text[i] <= '~' {
+			content[i] = text[i]
+		}
+	}
+	if beep {
+		// 62.5ms + 2/16 seconds; about the same as the DGT XL beep.
+		content[8] = 2
+	}
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_ASCII, content, ClockAckTimeout)
+}
+
+// Dot, semicolon and '1' symbols for ShowSegments.
+const (
+	CLOCK_DISPLAY_RIGHT_DOT       = 0x01
+	CLOCK_DISPLAY_RIGHT_SEMICOLON = 0x02
+	CLOCK_DISPLAY_RIGHT_ONE       = 0x04
+	CLOCK_DISPLAY_LEFT_DOT        = 0x08
+	CLOCK_DISPLAY_LEFT_SEMICOLON  = 0x10
+	CLOCK_DISPLAY_LEFT_ONE        = 0x20
+)
+
+// ShowSegments shows up to six characters on a clock with a
+// 7-segment display, such as the DGT XL, which can't show ASCII
+// text. The display is laid out as "1A:BC 1D:EF"; the characters of
+// the text fill A to F in order, and symbols is any combination of
+// the CLOCK_DISPLAY_* bits. Characters with no reasonable 7-segment
+// representation are shown as spaces.
+func (dgtboard *DgtBoard) ShowSegments(text string, symbols byte, beep bool) (*ClockAck, error) {
+	var segments [6]byte
+	for i := 0; i < len(segments) && i < len(text); i++ {
+		segments[i] = sevenSegments[text[i]]
+	}
+	var beepValue byte = 0x01
+	if beep {
+		beepValue = 0x03
+	}
+	// The clock wants the characters in the order C, B, A, F, E, D.
+	content := []byte{
+		segments[2], segments[1], segments[0],
+		segments[5], segments[4], segments[3],
+		symbols,
+		beepValue,
+	}
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_DISPLAY, content, ClockAckTimeout)
+}
+
+// sevenSegments maps characters to the segments which show them. The
+// bits are 0x01 top, 0x02 right top, 0x04 right bottom, 0x08 bottom,
+// 0x10 left bottom, 0x20 left top and 0x40 centre. Upper and lower
+// case are shown the same way, since there's no choice for most
+// letters.
+var sevenSegments = map[byte]byte{
+	'0': 0x3f, '1': 0x06, '2': 0x5b, '3': 0x4f, '4': 0x66,
+	'5': 0x6d, '6': 0x7d, '7': 0x07, '8': 0x7f, '9': 0x6f,
+	'a': 0x77, 'b': 0x7c, 'c': 0x58, 'd': 0x5e, 'e': 0x79,
+	'f': 0x71, 'g': 0x3d, 'h': 0x74, 'i': 0x10, 'j': 0x1e,
+	'l': 0x38, 'n': 0x54, 'o': 0x5c, 'p': 0x73, 'q': 0x67,
+	'r': 0x50, 's': 0x6d, 't': 0x78, 'u': 0x1c, 'y': 0x6e,
+	'A': 0x77, 'B': 0x7c, 'C': 0x39, 'D': 0x5e, 'E': 0x79,
+	'F': 0x71, 'G': 0x3d, 'H': 0x76, 'I': 0x30, 'J': 0x1e,
+	'L': 0x38, 'N': 0x37, 'O': 0x3f, 'P': 0x73, 'Q': 0x67,
+	'R': 0x50, 'S': 0x6d, 'T': 0x78, 'U': 0x3e, 'Y': 0x6e,
+	'-': 0x40, '_': 0x08, '=': 0x48, ' ': 0x00,
+}
+
+// Beep sounds the clock's beeper. The clock counts in units of 64ms,
+// so the duration is rounded to the nearest unit, with a minimum of
+// one and a maximum of 255.
+func (dgtboard *DgtBoard) Beep(duration time.Duration) (*ClockAck, error) {
+	units := (duration + 32*time.Millisecond) / (64 * time.Millisecond)
+	if units < 1 {
+		units = 1
+	}
+	if units > 255 {
+		units = 255
+	}
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_BEEP, []byte{byte(units)}, ClockAckTimeout)
+}
+
+// ClockRun says which side of the clock, if any, should run after a
+// SetAndRun.
+type ClockRun int
+
+const (
+	ClockPaused ClockRun = iota
+	ClockWhiteRuns
+	ClockBlackRuns
+)
+
+// SetAndRun sets the times on the clock and starts it running for one
+// side (or leaves it paused). The lever toggles the running side
+// afterwards, just as in a normal game. If countUp is set, the times
+// count up rather than down; the DGT XL (up to version 1.14, at least)
+// ignores this.
+//
+// The clock only accepts this command in mode 23; in any other mode,
+// the ack comes back as an error.
+//
+// White and Black are mapped onto the left and right of the clock
+// according to WhiteOnRight. Times of ten hours or more are shown as
+// 9:59:59.
+func (dgtboard *DgtBoard) SetAndRun(white, black time.Duration, run ClockRun, countUp bool) (*ClockAck, error) {
+	left, right := white, black
+	leftRuns, rightRuns := run == ClockWhiteRuns, run == ClockBlackRuns
+	if dgtboard.WhiteOnRight {
+		left, right = right, left
+		leftRuns, rightRuns = rightRuns, leftRuns
+	}
+
+	leftHours, leftMinutes, leftSeconds := encodeClockTime(left)
+	rightHours, rightMinutes, rightSeconds := encodeClockTime(right)
+	if countUp {
+		leftHours |= 0x10
+		rightHours |= 0x10
+	}
+
+	var flags byte = 0x08
+	switch {
+	case leftRuns:
+		flags |= 0x01
+	case rightRuns:
+		flags |= 0x02
+	default:
+		flags |= 0x04
+	}
+
+	content := []byte{
+		leftHours, leftMinutes, leftSeconds,
+		rightHours, rightMinutes, rightSeconds,
+		flags,
+	}
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_SETNRUN, content, ClockAckTimeout)
+}
+
+// Icon bits for ClockIcons.Left and ClockIcons.Right.
+const (
+	CLOCK_ICON_TIME   = 0x01
+	CLOCK_ICON_FISCH  = 0x02
+	CLOCK_ICON_DELAY  = 0x04
+	CLOCK_ICON_HGLASS = 0x08
+	CLOCK_ICON_UPCNT  = 0x10
+	CLOCK_ICON_BYO    = 0x20
+	CLOCK_ICON_END    = 0x40
+)
+
+// Icon bits for ClockIcons.LeftPeriod and ClockIcons.RightPeriod.
+const (
+	CLOCK_ICON_PERIOD_1 = 0x01
+	CLOCK_ICON_PERIOD_2 = 0x02
+	CLOCK_ICON_PERIOD_3 = 0x04
+	CLOCK_ICON_PERIOD_4 = 0x08
+	CLOCK_ICON_PERIOD_5 = 0x10
+	CLOCK_ICON_FLAG     = 0x20
+)
+
+// Icon bits for ClockIcons.Special.
+const (
+	CLOCK_ICON_CLEAR       = 0x01
+	CLOCK_ICON_SOUND       = 0x02
+	CLOCK_ICON_BLACK_WHITE = 0x04
+	CLOCK_ICON_WHITE_BLACK = 0x08
+	CLOCK_ICON_BATTERY     = 0x10
+	CLOCK_ICON_KEEP        = 0x40
+)
+
+// ClockIcons is the set of icons to show with ShowIcons. Each field is
+// a combination of the CLOCK_ICON_* bits that apply to it.
+type ClockIcons struct {
+	Left        byte
+	Right       byte
+	LeftPeriod  byte
+	RightPeriod byte
+	Special     byte
+}
+
+// ShowIcons controls the icons (flags, periods, symbols and so on) on
+// the clock display.
+func (dgtboard *DgtBoard) ShowIcons(icons ClockIcons) (*ClockAck, error) {
+	content := []byte{
+		icons.Left & 0x7f,
+		icons.Right & 0x7f,
+		icons.LeftPeriod & 0x3f,
+		icons.RightPeriod & 0x3f,
+		icons.Special & 0x7f,
+		0x00, 0x00, 0x00,
+	}
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_ICONS, content, ClockAckTimeout)
+}
+
+// EndDisplay clears any text or icons, and goes back to showing the
+// clock times.
+func (dgtboard *DgtBoard) EndDisplay() (*ClockAck, error) {
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_END, nil, ClockAckTimeout)
+}
+
+// RequestButton asks the clock which button, if any, is currently
+// pressed. The answer is in the returned ack.
+func (dgtboard *DgtBoard) RequestButton() (*ClockAck, error) {
+	return dgtboard.SendClockCommand(DGT_CMD_CLOCK_BUTTON, nil, ClockAckTimeout)
+}
+
+// ClockVersion asks the clock for its firmware version, which comes
+// back as a major and minor number (e.g. 2 and 1 for version 2.1).
+func (dgtboard *DgtBoard) ClockVersion() (int, int, error) {
+	ack, err := dgtboard.SendClockCommand(DGT_CMD_CLOCK_VERSION, nil, ClockAckTimeout)
+	if err != nil {
+		return 0, 0, err
+	}
+	return int(ack.Ack2 >> 4), int(ack.Ack2 & 0x0f), nil
+}
+
+// ShowTexts shows each text from a channel on the clock in turn, until
+// the channel is closed. Since each text has to wait for the clock to
+// acknowledge it, this saves callers from being held up by the clock;
+// it's meant to be run in a goroutine. An empty text gives the display
+// back to the clock. Errors are logged, since there's nobody to return
+// them to.
+func (dgtboard *DgtBoard) ShowTexts(texts <-chan string, beep bool) {
+	for text := range texts {
+		var err error
+		if text == "" {
+			_, err = dgtboard.EndDisplay()
+		} else {
+			_, err = dgtboard.ShowText(text, beep)
+		}
+		if err != nil {
+			log.Println("Clock: " + err.Error())
+		}
+	}
+}
@@ -2,17 +2,54 @@ package godgt
 
 // The public API of DgtBoard.
 
-import "io"
+import (
+	"context"
+	"sync"
+)
 
 type DgtBoard struct {
-	port           io.ReadWriteCloser
-	bytesFromBoard []byte
+	port    Transport
+	decoder *FrameDecoder
 
-	// A channel for reading messages from the board.
+	// Clock commands are sent one at a time; clockAcks carries the
+	// acks back to whoever is waiting in SendClockCommand.
+	clockMutex sync.Mutex
+	clockAcks  chan *ClockAck
+
+	// Likewise, Identify is answered through infoUpdates.
+	identifyMutex sync.Mutex
+	infoUpdates   chan *InfoUpdate
+
+	// Run's cancel function, a channel which is closed once it has
+	// finished, and the error that ended it.
+	runMutex sync.Mutex
+	cancel   context.CancelFunc
+	done     chan struct{}
+	err      error
+
+	// A channel for reading messages from the board. It's closed
+	// when Run finishes.
 	MessagesFromBoard chan *Message
 
 	// A channel for sending commands to the board.
 	CommandsToBoard chan *Command
+
+	// Set WhiteOnRight if the clock is placed so that White's time
+	// is shown on the right hand side of its display. By default,
+	// White is assumed to be on the left.
+	WhiteOnRight bool
+
+	// BatteryStatus messages are marked as low when the battery is
+	// down to this percentage.
+	LowBattery int
+
+	// Set for a draughts board, whose dumps and field updates are
+	// sent as DraughtsBoardUpdate and DraughtsFieldUpdate messages.
+	draughts bool
+
+	// Set if the owner of a draughts board plays on the light
+	// squares rather than the dark ones.
+	DraughtsOnLightSquares bool
 }
 
 func (dgtboard *DgtBoard) WriteBytes(bytes []byte) (int, error) {
@@ -24,26 +61,81 @@ func (dgtboard *DgtBoard) WriteCommand(command byte) (int, error) {
 	return dgtboard.port.Write(bytes)
 }
 
+// Stop stops Run, and waits for it to finish. It does nothing if Run
+// hasn't been started.
+func (dgtboard *DgtBoard) Stop() {
+	dgtboard.runMutex.Lock()
+	cancel := dgtboard.cancel
+	done := dgtboard.done
+	dgtboard.runMutex.Unlock()
+
+	if cancel == nil {
+		return
+	}
+	cancel()
+	<-done
+}
+
+// Err returns the error that ended Run, or nil if Run is still going,
+// or was stopped.
+func (dgtboard *DgtBoard) Err() error {
+	dgtboard.runMutex.Lock()
+	defer dgtboard.runMutex.Unlock()
+
+	return dgtboard.err
+}
+
+// Close stops Run, if it's running, and closes the port.
 func (dgtboard *DgtBoard) Close() {
+	dgtboard.Stop()
 	if dgtboard.port != nil {
 		dgtboard.port.Close()
 	}
 }
 
-// NewBoard() ...
-func NewDgtBoard(portName string) *DgtBoard {
-	port, err := CreatePort(portName)
+// NewDgtBoard opens the named port (see OpenTransport for the names
+// understood) and returns a DgtBoard talking over it.
+func NewDgtBoard(portName string) (*DgtBoard, error) {
+	transport, err := OpenTransport(portName)
+	if err != nil {
+		return nil, err
+	}
+	return NewDgtBoardFromTransport(transport), nil
+}
 
+// NewDgtDraughtsBoard is NewDgtBoard for a 10x10 draughts board.
+func NewDgtDraughtsBoard(portName string) (*DgtBoard, error) {
+	transport, err := OpenTransport(portName)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
+	return NewDgtDraughtsBoardFromTransport(transport), nil
+}
+
+// NewDgtDraughtsBoardFromTransport is NewDgtBoardFromTransport for a
+// 10x10 draughts board. The draughts piece codes overlap with the chess
+// ones, and the board dump is a different size, so there's no telling
+// the two kinds of board apart from what they send.
+func NewDgtDraughtsBoardFromTransport(transport Transport) *DgtBoard {
+	dgtboard := NewDgtBoardFromTransport(transport)
+	dgtboard.decoder = NewDraughtsFrameDecoder()
+	dgtboard.draughts = true
+	return dgtboard
+}
 
+// NewDgtBoardFromTransport returns a DgtBoard talking over an already
+// opened transport.
+func NewDgtBoardFromTransport(transport Transport) *DgtBoard {
 	// What values here are sane?
 	messagesFromBoard := make(chan *Message, 1024)
 	commandsToBoard := make(chan *Command, 1024)
 
 	return &DgtBoard{
-		port:              port,
+		port:              transport,
+		decoder:           NewFrameDecoder(),
+		clockAcks:         make(chan *ClockAck, 1),
+		infoUpdates:       make(chan *InfoUpdate, 8),
+		LowBattery:        DefaultLowBattery,
 		MessagesFromBoard: messagesFromBoard,
 		CommandsToBoard:   commandsToBoard,
 	}
@@ -0,0 +1,564 @@
+package godgt
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ERR_BUS_MESSAGE_TRUNCATED = errors.New("Bus message: too short")
+var ERR_BUS_CHECKSUM = errors.New("Bus message: bad checksum")
+var ERR_BUS_NO_REPLY = errors.New("Bus board didn't answer")
+var ERR_NOT_IN_BUS_MODE = errors.New("Command not available in bus mode")
+
+// How long a board on the bus has to answer a command.
+const BusReplyTimeout = 500 * time.Millisecond
+
+// How long to listen for the answers to a broadcast ping. The boards
+// each wait a random time before answering, so that they don't all
+// talk at once.
+const BusDiscoveryWindow = 2 * time.Second
+
+// DgtBus talks to several boards chained together on one line, in bus
+// mode. Each board on the bus gets a DgtBoard of its own, which can be
+// used much like one with a line to itself: its messages arrive on its
+// own MessagesFromBoard channel, and commands written to it are sent to
+// its address. (Don't run its ReadLoop, though; the bus's ReadLoop does
+// the reading for all of them.)
+//
+// A board in bus mode only talks when it's spoken to, so it can't send
+// updates of its own accord. Instead, Poll asks each board in one of
+// the update modes for its changes, which then arrive as the same
+// FieldUpdate (and TimeUpdate) messages as they would in single-board
+// mode.
+type DgtBus struct {
+	port    Transport
+	decoder *FrameDecoder
+
+	// Commands are sent one at a time, and each answer is waited for
+	// before sending the next, so that the boards never talk over
+	// each other. replies carries the address of every board heard
+	// from back to whoever is waiting in SendBusCommand.
+	commandMutex sync.Mutex
+	replies      chan int
+
+	mutex sync.Mutex
+	ports map[int]*busPort
+
+	// Set once ReadLoop has finished, and closed the boards'
+	// MessagesFromBoard. done is closed at the same time, to give up
+	// on any message still waiting to be read, and senders counts
+	// those messages, so that the channels aren't closed under them.
+	finished bool
+	done     chan struct{}
+	senders  sync.WaitGroup
+
+	// The boards that have answered a broadcast ping, while Discover
+	// is listening.
+	pinged map[int]bool
+}
+
+// NewDgtBus opens the named port (see OpenTransport) for a bus of
+// boards.
+func NewDgtBus(portName string) (*DgtBus, error) {
+	transport, err := OpenTransport(portName)
+	if err != nil {
+		return nil, err
+	}
+	return NewDgtBusFromTransport(transport), nil
+}
+
+// NewDgtBusFromTransport returns a DgtBus talking over an already
+// opened transport.
+func NewDgtBusFromTransport(transport Transport) *DgtBus {
+	return &DgtBus{
+		port:    transport,
+		decoder: NewBusFrameDecoder(),
+		replies: make(chan int, 16),
+		ports:   make(map[int]*busPort),
+		done:    make(chan struct{}),
+	}
+}
+
+// ToBusMode switches every board on the line to bus mode. (A board
+// also switches as soon as it receives a bus command, so this is only
+// really needed to make sure that none of them are still talking in
+// single-board mode.)
+func (bus *DgtBus) ToBusMode() error {
+	_, err := bus.port.Write([]byte{DGT_TO_BUSMODE})
+	return err
+}
+
+// EndBusMode returns every board known about to single-board mode.
+func (bus *DgtBus) EndBusMode() error {
+	for _, address := range bus.Addresses() {
+		err := bus.SendBusCommand(address, DGT_BUS_END_BUSMODE)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (bus *DgtBus) Close() {
+	bus.mutex.Lock()
+	for _, port := range bus.ports {
+		port.Close()
+	}
+	bus.mutex.Unlock()
+	bus.port.Close()
+}
+
+// Board returns the DgtBoard for the board with the given bus address,
+// making one if it isn't known about yet. This is how to use a board
+// whose address is already known (it's the same as the one returned by
+// DGT_RETURN_BUSADRES in single-board mode) without running Discover.
+func (bus *DgtBus) Board(address int) *DgtBoard {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	port, ok := bus.ports[address]
+	if !ok {
+		port = &busPort{
+			bus:     bus,
+			address: address,
+			mode:    DGT_SEND_RESET,
+			closed:  make(chan struct{}),
+		}
+		port.board = NewDgtBoardFromTransport(port)
+		if bus.finished {
+			close(port.board.MessagesFromBoard)
+		}
+		bus.ports[address] = port
+	}
+	return port.board
+}
+
+// Addresses returns the addresses of the boards known about, in order.
+func (bus *DgtBus) Addresses() []int {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	var addresses []int
+	for address := range bus.ports {
+		addresses = append(addresses, address)
+	}
+	sort.Ints(addresses)
+	return addresses
+}
+
+// Discover finds the boards on the bus by pinging them all, and making
+// a DgtBoard for each one that answers. Two boards answering at the
+// same moment garble each other's answers, so the boards already known
+// about are told to ignore the next ping, and the rest are pinged
+// again, until a ping finds nobody new or the context is done. It
+// returns the addresses of all the boards known about.
+//
+// The answers are read by ReadLoop, which must be running.
+func (bus *DgtBus) Discover(ctx context.Context) ([]int, error) {
+	for {
+		known := bus.Addresses()
+		for _, address := range known {
+			err := bus.SendBusCommand(address, DGT_BUS_IGNORE_NEXT_BUS_PING)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		found, err := bus.ping(ctx)
+		if err != nil {
+			return nil, err
+		}
+		newBoards := 0
+		for address := range found {
+			if bus.known(address) {
+				continue
+			}
+			log.Printf("Found a board at bus address %d\n", address)
+			bus.Board(address)
+			newBoards++
+		}
+		if newBoards == 0 || ctx.Err() != nil {
+			return bus.Addresses(), nil
+		}
+	}
+}
+
+// ping sends a broadcast ping, and returns the addresses of the boards
+// which answered it.
+func (bus *DgtBus) ping(ctx context.Context) (map[int]bool, error) {
+	// Nothing else can be sent while the boards are answering.
+	bus.commandMutex.Lock()
+	defer bus.commandMutex.Unlock()
+
+	bus.mutex.Lock()
+	bus.pinged = make(map[int]bool)
+	bus.mutex.Unlock()
+
+	_, err := bus.port.Write(encodeBusCommand(DGT_BUS_PING, 0))
+	if err == nil {
+		select {
+		case <-time.After(BusDiscoveryWindow):
+		case <-ctx.Done():
+		}
+	}
+
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+	pinged := bus.pinged
+	bus.pinged = nil
+	return pinged, err
+}
+
+func (bus *DgtBus) known(address int) bool {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	_, ok := bus.ports[address]
+	return ok
+}
+
+// SendBusCommand sends a bus command to the board with the given
+// address. If the command has an answer, it waits for the board to
+// start answering (the answer itself arrives on the board's
+// MessagesFromBoard), and returns ERR_BUS_NO_REPLY if it doesn't.
+func (bus *DgtBus) SendBusCommand(address int, command byte) error {
+	bus.commandMutex.Lock()
+	defer bus.commandMutex.Unlock()
+
+	// Forget about anyone who answered too late last time.
+	for {
+		select {
+		case <-bus.replies:
+			continue
+		default:
+		}
+		break
+	}
+
+	_, err := bus.port.Write(encodeBusCommand(command, address))
+	if err != nil {
+		return err
+	}
+	if address == 0 || !busCommandAnswered(command) {
+		return nil
+	}
+
+	timeout := time.After(BusReplyTimeout)
+	for {
+		select {
+		case from := <-bus.replies:
+			if from == address {
+				return nil
+			}
+		case <-timeout:
+			return ERR_BUS_NO_REPLY
+		}
+	}
+}
+
+func busCommandAnswered(command byte) bool {
+	switch command {
+	case DGT_BUS_SEND_CLK, DGT_BUS_SEND_BRD, DGT_BUS_SEND_CHANGES,
+		DGT_BUS_REPEAT_CHANGES, DGT_BUS_SEND_FROM_START, DGT_BUS_PING:
+		return true
+	}
+	return false
+}
+
+// encodeBusCommand returns the four bytes of a bus command; see the
+// description of bus mode in dgtconstants.go.
+func encodeBusCommand(command byte, address int) []byte {
+	bytes := []byte{
+		command,
+		byte(address>>7) & 0x7f,
+		byte(address) & 0x7f,
+	}
+	return append(bytes, busChecksum(bytes))
+}
+
+func busChecksum(bytes []byte) byte {
+	var sum byte
+	for _, b := range bytes {
+		sum += b
+	}
+	return sum & 0x7f
+}
+
+// Poll asks every board that's in one of the update modes for its
+// changes, and those in DGT_SEND_UPDATE or DGT_SEND_UPDATE_NICE mode for
+// the clock too, every interval until the context is done.
+func (bus *DgtBus) Poll(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		bus.mutex.Lock()
+		var ports []*busPort
+		for _, port := range bus.ports {
+			ports = append(ports, port)
+		}
+		bus.mutex.Unlock()
+		for _, port := range ports {
+			port.poll()
+		}
+	}
+}
+
+// ReadLoop reads the messages from all the boards on the bus, and
+// passes each one on to its board's MessagesFromBoard, until reading
+// fails (or the bus is closed). Then it closes every board's
+// MessagesFromBoard.
+func (bus *DgtBus) ReadLoop() {
+	defer bus.finish()
+
+	buf := make([]byte, 1024)
+	for {
+		n, err := bus.port.Read(buf)
+		if n > 0 {
+			bus.decoder.Write(buf[:n])
+		}
+		for frame := bus.decoder.Next(); frame != nil; frame = bus.decoder.Next() {
+			err := bus.handleFrame(frame)
+			if err != nil {
+				log.Println(err)
+			}
+		}
+		if err != nil {
+			log.Println("Bus: " + err.Error())
+			return
+		}
+	}
+}
+
+func (bus *DgtBus) finish() {
+	bus.mutex.Lock()
+	defer bus.mutex.Unlock()
+
+	bus.finished = true
+	close(bus.done)
+	bus.senders.Wait()
+	for _, port := range bus.ports {
+		close(port.board.MessagesFromBoard)
+	}
+}
+
+// send passes a message on to a board's MessagesFromBoard, unless the
+// bus has finished. It doesn't hold the mutex while it waits for the
+// message to be read, since whoever is reading it may well want the
+// mutex too (to send a command, for example).
+func (bus *DgtBus) send(board *DgtBoard, message *Message) {
+	bus.mutex.Lock()
+	if bus.finished {
+		bus.mutex.Unlock()
+		return
+	}
+	bus.senders.Add(1)
+	bus.mutex.Unlock()
+	defer bus.senders.Done()
+
+	select {
+	case board.MessagesFromBoard <- message:
+	case <-bus.done:
+	}
+}
+
+// FrameStats returns the frame decoder's counters, which are useful
+// for spotting a noisy line.
+func (bus *DgtBus) FrameStats() FrameStats {
+	return bus.decoder.Stats()
+}
+
+func (bus *DgtBus) handleFrame(frame *Frame) error {
+	// The data is the address, whatever the message has to say, and
+	// the checksum, which covers the header too.
+	data := frame.Data
+	if len(data) < 3 {
+		return ERR_BUS_MESSAGE_TRUNCATED
+	}
+	length := len(data) + 3
+	header := []byte{frame.Id | MESSAGE_BIT, byte(length>>7) & 0x7f, byte(length) & 0x7f}
+	checksum := busChecksum(append(header, data[:len(data)-1]...))
+	if checksum != data[len(data)-1] {
+		return ERR_BUS_CHECKSUM
+	}
+	address := int(data[0])<<7 | int(data[1])
+	arguments := data[2 : len(data)-1]
+
+	select {
+	case bus.replies <- address:
+	default:
+		// Nobody is waiting for it.
+	}
+
+	if frame.Id == DGT_BUS_PING_REPLY {
+		bus.mutex.Lock()
+		if bus.pinged != nil {
+			bus.pinged[address] = true
+		}
+		bus.mutex.Unlock()
+		return nil
+	}
+
+	board := bus.Board(address)
+	var messages []*Message
+	switch frame.Id {
+	case DGT_BUS_BRD_DUMP:
+		message, err := board.handleBoardDump(arguments)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	case DGT_BUS_CLK_DATA:
+		message, err := board.handleTime(arguments)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	case DGT_BUS_UPDATE:
+		// The changes are passed on one by one, as they would have
+		// been sent in single-board mode.
+		message, err := board.handleEEMoves(arguments)
+		if err != nil {
+			return err
+		}
+		messages = message.EEMoves.Messages()
+	case DGT_BUS_FROM_START:
+		// Whereas the whole game is passed on in one go, as the
+		// answer to DGT_SEND_EE_MOVES is.
+		message, err := board.handleEEMoves(arguments)
+		if err != nil {
+			return err
+		}
+		messages = append(messages, message)
+	default:
+		return ERR_PARSE_FAILED
+	}
+	for _, message := range messages {
+		if message != nil {
+			bus.send(board, message)
+		}
+	}
+	return nil
+}
+
+// busPort is the Transport of a DgtBoard on a bus. It turns the
+// single-board commands written to it into the nearest bus commands,
+// addressed to its board.
+type busPort struct {
+	bus     *DgtBus
+	address int
+	board   *DgtBoard
+
+	mutex sync.Mutex
+	// The last of DGT_SEND_RESET, DGT_SEND_UPDATE, DGT_SEND_UPDATE_BRD
+	// or DGT_SEND_UPDATE_NICE written, which says what to poll for.
+	mode byte
+	// Set when the board didn't answer the last poll, so that it's
+	// asked to repeat the changes rather than send new ones.
+	repeat bool
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Write sends the single-byte commands which have a bus equivalent,
+// and remembers the update mode. Other single-byte commands are
+// ignored, as a board ignores commands it doesn't know; the clock and
+// LED commands can't be sent in bus mode at all, and fail with
+// ERR_NOT_IN_BUS_MODE.
+func (bp *busPort) Write(p []byte) (int, error) {
+	if len(p) > 0 && (p[0] == DGT_CLOCK_MESSAGE || p[0] == DGT_SET_LEDS) {
+		return 0, ERR_NOT_IN_BUS_MODE
+	}
+	for i, command := range p {
+		err := bp.writeCommand(command)
+		if err != nil {
+			return i, err
+		}
+	}
+	return len(p), nil
+}
+
+func (bp *busPort) writeCommand(command byte) error {
+	switch command {
+	case DGT_SEND_RESET, DGT_SEND_UPDATE, DGT_SEND_UPDATE_BRD,
+		DGT_SEND_UPDATE_NICE:
+		bp.mutex.Lock()
+		bp.mode = command
+		bp.mutex.Unlock()
+		return nil
+	case DGT_SEND_BRD:
+		return bp.bus.SendBusCommand(bp.address, DGT_BUS_SEND_BRD)
+	case DGT_SEND_CLK:
+		return bp.bus.SendBusCommand(bp.address, DGT_BUS_SEND_CLK)
+	case DGT_SEND_EE_MOVES:
+		// Not quite the same: only the moves since the start of the
+		// game rather than the whole storage.
+		return bp.bus.SendBusCommand(bp.address, DGT_BUS_SEND_FROM_START)
+	case DGT_RETURN_BUSADRES:
+		// No need to ask.
+		message, err := bp.board.handleBusAddress([]byte{
+			byte(bp.address>>7) & 0x7f,
+			byte(bp.address) & 0x7f,
+		})
+		if err != nil {
+			return err
+		}
+		bp.bus.send(bp.board, message)
+		return nil
+	default:
+		log.Printf("Bus board %d: ignoring command 0x%02x\n", bp.address, command)
+		return nil
+	}
+}
+
+func (bp *busPort) poll() {
+	bp.mutex.Lock()
+	mode := bp.mode
+	command := byte(DGT_BUS_SEND_CHANGES)
+	if bp.repeat {
+		command = DGT_BUS_REPEAT_CHANGES
+	}
+	bp.mutex.Unlock()
+
+	if mode == DGT_SEND_RESET {
+		return
+	}
+	err := bp.bus.SendBusCommand(bp.address, command)
+	bp.mutex.Lock()
+	bp.repeat = err == ERR_BUS_NO_REPLY
+	bp.mutex.Unlock()
+	if err != nil {
+		log.Printf("Bus board %d: %s\n", bp.address, err)
+		return
+	}
+	if mode == DGT_SEND_UPDATE || mode == DGT_SEND_UPDATE_NICE {
+		err = bp.bus.SendBusCommand(bp.address, DGT_BUS_SEND_CLK)
+		if err != nil {
+			log.Printf("Bus board %d: %s\n", bp.address, err)
+		}
+	}
+}
+
+// Read blocks until the port is closed, since everything from the
+// board is read by the bus.
+func (bp *busPort) Read(p []byte) (int, error) {
+	<-bp.closed
+	return 0, io.EOF
+}
+
+func (bp *busPort) Close() error {
+	bp.closeOnce.Do(func() {
+		close(bp.closed)
+	})
+	return nil
+}
@@ -170,6 +170,9 @@ const DGT_CLOCK_MESSAGE = 0x2b
  *   ack message is returned.
  */
 
+const DGT_CMD_CLOCK_START_MESSAGE = 0x03
+const DGT_CMD_CLOCK_END_MESSAGE = 0x00
+
 const DGT_CMD_CLOCK_DISPLAY = 0x01
 
 /*
@@ -679,3 +682,160 @@ const DGT_MSG_EE_MOVES = (MESSAGE_BIT | DGT_EE_MOVES)
  * starting with the oldest data, until the last written changes, and will
  * always end with EE_EOF
  */
+
+/* ------------------------------------------------------------------------ */
+/* Description of the EEPROM data storage and dump format                   */
+/* ------------------------------------------------------------------------ */
+/*
+ * The storage is a stream of tagged records, oldest first. The first byte
+ * of each record determines its length:
+ *
+ * 0x40-0x4f: field change, 2 bytes.
+ *            byte 1: 0x40 | piece code (EMPTY when a piece was lifted)
+ *            byte 2: field number (0-63)
+ * 0x60-0x69: clock time of the left player, 3 bytes.
+ *            byte 1: 0x60 | hours (0-9)
+ *            byte 2: minutes (BCD)
+ *            byte 3: seconds (BCD)
+ * 0x70-0x79: clock time of the right player, coded as for the left
+ *            player.
+ * EE_POWERUP: board switched on, 65 bytes. The tag is followed by a
+ *            complete board dump: 64 piece codes, fields 0-63.
+ *
+ * All other tags are single bytes.
+ */
+
+const EE_FIELDCHANGE = 0x40     /* 0x40 | piece code, then the field */
+const EE_LEFT_TIME = 0x60       /* 0x60 | hours, then minutes, seconds */
+const EE_RIGHT_TIME = 0x70      /* 0x70 | hours, then minutes, seconds */
+const EE_POWERUP = 0x6a         /* board switched on, board dump follows */
+const EE_EOF = 0x6b             /* end of the storage */
+const EE_FOURROWS = 0x6c        /* four rows of pieces: a new game? */
+const EE_EMPTYBOARD = 0x6d      /* all pieces removed */
+const EE_DOWNLOADED = 0x6e      /* the storage has been downloaded */
+const EE_BEGINPOS = 0x6f        /* start position, white at connector */
+const EE_BEGINPOS_ROT = 0x7a    /* start position, black at connector */
+const EE_START_TAG = 0x7b       /* start of the storage */
+const EE_WATCHDOG_ACTION = 0x7c /* the watchdog reset the board */
+const EE_FUTURE_1 = 0x7d        /* reserved */
+const EE_FUTURE_2 = 0x7e        /* reserved */
+const EE_NOP = 0x7f             /* filler */
+const EE_NOP2 = 0x00            /* filler */
+const DGT_SIZE_EE_MOVES = 0x2000 - 0x100 + 3
+
+/* ------------------------------------------------------------------------ */
+/* Bus mode                                                                 */
+/* ------------------------------------------------------------------------ */
+/*
+ * After DGT_TO_BUSMODE (or any bus command), a board only talks when it is
+ * spoken to, so that several boards can share one line. Every bus command
+ * is 4 bytes:
+ *
+ * byte 0: the command
+ * byte 1: bus address MSB 7 bits
+ * byte 2: bus address LSB 7 bits
+ * byte 3: checksum: the sum of bytes 0-2, AND 0x7f
+ *
+ * The bus address is the one returned by DGT_RETURN_BUSADRES. Address 0 is
+ * a broadcast, which is only useful with DGT_BUS_PING.
+ */
+
+const DGT_BUS_SEND_CLK = (MESSAGE_BIT | 0x01)
+
+/* Results in a DGT_MSG_BUS_CLK_DATA message
+ */
+
+const DGT_BUS_SEND_BRD = (MESSAGE_BIT | 0x02)
+
+/* Results in a DGT_MSG_BUS_BRD_DUMP message
+ */
+
+const DGT_BUS_SEND_CHANGES = (MESSAGE_BIT | 0x03)
+
+/* Results in a DGT_MSG_BUS_UPDATE message, with the changes since the last
+ * DGT_BUS_SEND_CHANGES.
+ */
+
+const DGT_BUS_REPEAT_CHANGES = (MESSAGE_BIT | 0x04)
+
+/* Results in the same DGT_MSG_BUS_UPDATE message as the last
+ * DGT_BUS_SEND_CHANGES, for when the answer got lost.
+ */
+
+const DGT_BUS_SET_START_GAME = (MESSAGE_BIT | 0x05)
+
+/* Marks the start of a game in the storage. No message is returned.
+ */
+
+const DGT_BUS_SEND_FROM_START = (MESSAGE_BIT | 0x06)
+
+/* Results in a DGT_MSG_BUS_FROM_START message, with all the changes since
+ * the last DGT_BUS_SET_START_GAME.
+ */
+
+const DGT_BUS_PING = (MESSAGE_BIT | 0x07)
+
+/* Results in a DGT_MSG_BUS_PING message. Sent to the broadcast address,
+ * every board answers, each after a random delay.
+ */
+
+const DGT_BUS_END_BUSMODE = (MESSAGE_BIT | 0x08)
+
+/* Returns the board to single-board mode. No message is returned.
+ */
+
+const DGT_BUS_RESET = (MESSAGE_BIT | 0x09)
+
+/* Resets the board, as though it had been switched off and on again. No
+ * message is returned.
+ */
+
+const DGT_BUS_IGNORE_NEXT_BUS_PING = (MESSAGE_BIT | 0x0a)
+
+/* The board doesn't answer the next broadcast DGT_BUS_PING, which gives the
+ * boards that haven't been heard from yet a chance to be. No message is
+ * returned.
+ */
+
+/* Bus mode ID codes: */
+const DGT_BUS_BRD_DUMP = 0x03
+const DGT_BUS_CLK_DATA = 0x04
+const DGT_BUS_FROM_START = 0x05
+const DGT_BUS_UPDATE = 0x06
+const DGT_BUS_PING_REPLY = 0x07
+
+/* Every bus message has the same layout:
+ *
+ * byte 0: message ID
+ * byte 1: LLH_SEVEN(size)
+ * byte 2: LLL_SEVEN(size)
+ * byte 3: bus address MSB 7 bits
+ * byte 4: bus address LSB 7 bits
+ * byte 5-(size-2): data, as below
+ * byte size-1: checksum: the sum of all the bytes before it, AND 0x7f
+ */
+
+const DGT_MSG_BUS_BRD_DUMP = (MESSAGE_BIT | DGT_BUS_BRD_DUMP)
+const DGT_SIZE_BUS_BRD_DUMP = 70
+
+/* data: 64 piece codes, as for DGT_MSG_BOARD_DUMP
+ */
+
+const DGT_MSG_BUS_CLK_DATA = (MESSAGE_BIT | DGT_BUS_CLK_DATA)
+const DGT_SIZE_BUS_CLK_DATA = 13
+
+/* data: 7 bytes, as for DGT_MSG_BWTIME
+ */
+
+const DGT_MSG_BUS_FROM_START = (MESSAGE_BIT | DGT_BUS_FROM_START)
+const DGT_MSG_BUS_UPDATE = (MESSAGE_BIT | DGT_BUS_UPDATE)
+
+/* data: any number of records from the storage, in the format of
+ * DGT_MSG_EE_MOVES (see above).
+ */
+
+const DGT_MSG_BUS_PING = (MESSAGE_BIT | DGT_BUS_PING_REPLY)
+const DGT_SIZE_BUS_PING = 6
+
+/* no data
+ */
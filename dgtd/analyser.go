@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
+	"github.com/malbrecht/chess/engine"
+	"github.com/malbrecht/chess/engine/uci"
+)
+
+// How long to wait before restarting an engine that has died.
+const restartDelay = 2 * time.Second
+
+// analyser keeps a UCI engine analysing the position on the board.
+// Whenever the position changes, the search is stopped and started
+// again on the new one. If the engine dies, it's restarted.
+type analyser struct {
+	path    string
+	multiPV int
+
+	// The next position to analyse; only the most recent one
+	// matters, so there's room for just one.
+	positions chan *chess.Board
+
+	// The analysis so far, each time the engine completes a depth.
+	Results chan *godgt.PositionAnalysis
+}
+
+func newAnalyser(path string, multiPV int) *analyser {
+	return &analyser{
+		path:      path,
+		multiPV:   multiPV,
+		positions: make(chan *chess.Board, 1),
+		Results:   make(chan *godgt.PositionAnalysis, 16),
+	}
+}
+
+// Analyse replaces the position being analysed. A nil board stops the
+// analysis until there's a position again.
+func (a *analyser) Analyse(board *chess.Board) {
+	for {
+		select {
+		case a.positions <- board:
+			return
+		default:
+			// Throw away the one that hasn't been looked at
+			// yet, since it's out of date.
+			select {
+			case <-a.positions:
+			default:
+			}
+		}
+	}
+}
+
+func (a *analyser) run() {
+	var board *chess.Board
+	var engine *uci.Engine
+	for {
+		if board == nil {
+			board = <-a.positions
+			continue
+		}
+		if engine == nil {
+			var err error
+			engine, err = a.start()
+			if err != nil {
+				log.Println("Analysis engine: " + err.Error())
+				select {
+				case board = <-a.positions:
+				case <-time.After(restartDelay):
+				}
+				continue
+			}
+		}
+		board, engine = a.analyse(engine, board)
+		if engine == nil {
+			log.Println("Analysis engine died; restarting it.")
+			time.Sleep(restartDelay)
+		}
+	}
+}
+
+func (a *analyser) start() (*uci.Engine, error) {
+	engine, err := uci.Run(a.path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	option, ok := engine.Options()["MultiPV"]
+	if ok {
+		option.Set(fmt.Sprintf("%d", a.multiPV))
+	} else if a.multiPV > 1 {
+		log.Println("Analysis engine has no MultiPV option; showing one line.")
+		a.multiPV = 1
+	}
+	return engine, nil
+}
+
+// analyse runs an infinite search on a position until there's a new
+// one. It returns the new position, and the engine, or nil if the
+// engine died, in which case the position is the one it died on.
+func (a *analyser) analyse(engine *uci.Engine, board *chess.Board) (*chess.Board, *uci.Engine) {
+	legalMoves := len(board.LegalMoves())
+	if legalMoves == 0 {
+		// Mate or stalemate; nothing to analyse.
+		return <-a.positions, engine
+	}
+	lines := a.multiPV
+	if lines > legalMoves {
+		lines = legalMoves
+	}
+
+	engine.SetPosition(board)
+	infos := engine.Search()
+	analysis := &godgt.PositionAnalysis{
+		Fen:       board.Fen(),
+		BestMoves: make([]godgt.ScoredMove, lines),
+	}
+
+	for {
+		select {
+		case next := <-a.positions:
+			engine.Stop()
+			for range infos {
+				// Wait for the engine to finish the search.
+			}
+			return next, engine
+		case info, ok := <-infos:
+			if !ok {
+				// An infinite search only ends when it's
+				// stopped.
+				engine.Quit()
+				return board, nil
+			}
+			if info.Err() != nil {
+				log.Println("Analysis engine: " + info.Err().Error())
+				engine.Quit()
+				return board, nil
+			}
+			pv := info.Pv()
+			if pv == nil || pv.Rank >= lines || len(pv.Moves) == 0 {
+				continue
+			}
+			analysis.BestMoves[pv.Rank] = scoreLine(board, pv)
+			if stats := info.Stats(); stats != nil {
+				analysis.Depth = stats.Depth
+			}
+			if pv.Rank == lines-1 {
+				// That's all the lines for this depth.
+				a.report(analysis)
+			}
+		}
+	}
+}
+
+// report sends a copy of the analysis so far.
+func (a *analyser) report(analysis *godgt.PositionAnalysis) {
+	snapshot := *analysis
+	snapshot.BestMoves = append([]godgt.ScoredMove{}, analysis.BestMoves...)
+	select {
+	case a.Results <- &snapshot:
+	default:
+		// Nobody's keeping up; there'll be another one along
+		// shortly.
+	}
+}
+
+// scoreLine turns one of the engine's lines into a ScoredMove, with
+// the moves in SAN.
+func scoreLine(board *chess.Board, pv *engine.Pv) godgt.ScoredMove {
+	sm := godgt.ScoredMove{
+		// Add 1 so the best move has rank 1, as in ratemygame.
+		Rank:  pv.Rank + 1,
+		Move:  pv.Moves[0].San(board),
+		Score: float64(pv.Score) / 100.0,
+		Mate:  pv.Mate,
+	}
+	for _, move := range pv.Moves {
+		sm.Pv = append(sm.Pv, move.San(board))
+		board = board.MakeMove(move)
+	}
+	return sm
+}
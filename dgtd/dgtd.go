@@ -1,37 +1,267 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"log"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/jessevdk/go-flags"
 	"github.com/kgigitdev/godgt"
 )
 
+var opts struct {
+	Port string `short:"p" long:"port" description:"Serial port, tcp:host:port or file:recording (or give it as the only argument)" default:"/dev/ttyUSB0" env:"DGT_PORT"`
+
+	Pgn string `long:"pgn" description:"Record games to this PGN file"`
+
+	Event string `long:"event" description:"PGN Event tag"`
+	Site  string `long:"site" description:"PGN Site tag"`
+	Date  string `long:"date" description:"PGN Date tag (default: the day each game starts)"`
+	Round string `long:"round" description:"PGN Round tag"`
+	White string `long:"white" description:"PGN White tag"`
+	Black string `long:"black" description:"PGN Black tag"`
+
+	Gestures string `long:"gestures" description:"Read extra board gestures from this file"`
+
+	Http string `long:"http" description:"Show the board on a web page at this address, e.g. localhost:8080"`
+
+	Leds bool `long:"leds" description:"Use the LEDs of a Revelation II to show moves and the squares that need putting right"`
+
+	Analyse     string `long:"analyse" description:"Keep this UCI engine analysing the position on the board"`
+	MultiPV     int    `long:"multipv" description:"Number of lines for the engine to show" default:"3"`
+	AnalysisLog string `long:"analysis-log" description:"Append the analysis to this file, as JSON lines"`
+
+	Battery time.Duration `long:"battery" description:"How often to check the battery of a Bluetooth board (0: never)" default:"5m"`
+}
+
 func main() {
-	if len(os.Args) < 2 {
-		fmt.Printf("Usage: %s /path/to/usbdevice\n", os.Args[0])
-		fmt.Printf("e.g.:  %s /dev/ttyUSB0\n", os.Args[0])
+	args, err := flags.ParseArgs(&opts, os.Args)
+
+	if err != nil {
+		os.Exit(1)
+	}
+
+	// For compatibility with earlier versions, the port can still
+	// be given on its own, e.g. "dgtd /dev/ttyUSB0".
+	if len(args) > 1 {
+		opts.Port = args[1]
+	}
+
+	dgtboard, err := godgt.NewDgtBoard(opts.Port)
+	if err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 
-	portName := os.Args[1]
-	dgtboard := godgt.NewDgtBoard(portName)
+	var recorder *godgt.GameRecorder
+	if opts.Pgn != "" {
+		recorder, err = godgt.NewGameRecorder(opts.Pgn, godgt.GameTags{
+			Event: opts.Event,
+			Site:  opts.Site,
+			Date:  opts.Date,
+			Round: opts.Round,
+			White: opts.White,
+			Black: opts.Black,
+		})
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
 
 	// FIXME: Make this properly channel based.
 	// FIXME: Make this part of the dgtboard class. Users should
 	// not need to know that they need to send these.
 	dgtboard.WriteCommand(godgt.DGT_SEND_RESET)
 	dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
-	dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_BRD)
+	// UPDATE_NICE rather than UPDATE_BRD, so that we hear about the
+	// clock as well.
+	dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_NICE)
 
 	mp := godgt.NewMessageProcessor()
+	if opts.Gestures != "" {
+		gestures, err := godgt.LoadGestures(opts.Gestures)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		// The file's gestures take priority, but the built-in ones
+		// are still there for anything it doesn't mention.
+		mp.Gestures = append(gestures, godgt.DefaultGestures()...)
+	}
+
+	// Once the pieces stop moving, the detector asks for a complete
+	// dump, so that the move processor can check that it's still in
+	// step with the board.
+	detector := godgt.NewStablePositionDetector(dgtboard, godgt.DefaultQuietPeriod)
+
+	// Stop cleanly when asked to, so that the port is closed and
+	// everything is written out.
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		log.Println("Shutting down.")
+		stop()
+	}()
+
+	go dgtboard.Run(ctx)
+	go detector.Run()
+
+	// With several boards in use, it helps to know which one the
+	// logs and the games came from.
+	identifyCtx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	info, err := dgtboard.Identify(identifyCtx)
+	cancel()
+	if err != nil {
+		log.Println(err)
+	} else {
+		log.Println(info.ToString())
+		if serial := info.Serial(); serial != "" {
+			log.SetPrefix("[" + serial + "] ")
+			if recorder != nil {
+				recorder.Tags.Board = serial
+			}
+		}
+	}
 
-	go dgtboard.ReadLoop()
+	var view *webView
+	if opts.Http != "" {
+		view = newWebView()
+		go view.serve(opts.Http)
+	}
+
+	// When the pieces don't match the game, the clock says what
+	// needs putting right, one square at a time.
+	clockTexts := make(chan string, 16)
+	go dgtboard.ShowTexts(clockTexts, true)
+	showingMismatch := false
+
+	if opts.Battery > 0 {
+		go dgtboard.PollBattery(ctx, opts.Battery)
+	}
+
+	var analysis *analyser
+	var analysisResults chan *godgt.PositionAnalysis
+	var analysisLog *os.File
+	analysedFen := ""
+	if opts.Analyse != "" {
+		analysis = newAnalyser(opts.Analyse, opts.MultiPV)
+		analysisResults = analysis.Results
+		go analysis.run()
+	}
+	if opts.AnalysisLog != "" {
+		analysisLog, err = os.OpenFile(opts.AnalysisLog,
+			os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer analysisLog.Close()
+	}
 
 	for {
 		select {
-		case message := <-dgtboard.MessagesFromBoard:
+		case message, ok := <-detector.Messages:
+			if !ok {
+				// The board has been stopped, or has gone away.
+				if err := dgtboard.Err(); err != nil {
+					log.Println(err)
+					os.Exit(1)
+				}
+				return
+			}
+			if message.BatteryStatus != nil {
+				fmt.Println(message.BatteryStatus.ToString())
+				if message.BatteryStatus.Low && mp.Clock != nil {
+					select {
+					case clockTexts <- message.BatteryStatus.ClockText():
+					default:
+					}
+				}
+			}
 			mp.ProcessMessage(message)
+		case event := <-mp.Events:
+			fmt.Println(event.ToString())
+			if event.StateEvent != nil {
+				diff := event.StateEvent.Diff
+				if view != nil {
+					view.update(event.StateEvent.Fen, diff.Squares())
+				}
+				// Without a clock, there's nobody to acknowledge
+				// the text, so don't bother.
+				if mp.Clock != nil && (len(diff) > 0 || showingMismatch) {
+					select {
+					case clockTexts <- diff.ClockText():
+					default:
+					}
+					showingMismatch = len(diff) > 0
+				}
+				if opts.Leds {
+					err := dgtboard.LightSquares(diff.Squares())
+					if err != nil {
+						log.Println(err)
+					}
+				}
+			} else if view != nil && mp.Board != nil {
+				view.update(mp.Board.Fen(), nil)
+			}
+			if opts.Leds && event.MoveEvent != nil {
+				err := dgtboard.LightMove(event.MoveEvent.Move)
+				if err != nil {
+					log.Println(err)
+				}
+			}
+			if recorder != nil {
+				err := recorder.ProcessEvent(event)
+				if err == nil && event.SignalEvent != nil &&
+					event.SignalEvent.Action == godgt.ActionSaveGame {
+					err = recorder.Write()
+				}
+				if err != nil {
+					log.Println(err)
+				}
+			}
+			if analysis != nil {
+				// Analyse the game's position (if there is one)
+				// whenever it changes.
+				board := mp.Board
+				if mp.AwaitingSetup {
+					board = nil
+				}
+				fen := ""
+				if board != nil {
+					fen = board.Fen()
+				}
+				if fen != analysedFen {
+					analysedFen = fen
+					analysis.Analyse(board)
+					if view != nil {
+						view.updateAnalysis("")
+					}
+				}
+			}
+		case result := <-analysisResults:
+			if result.Fen != analysedFen {
+				// Already out of date.
+				continue
+			}
+			fmt.Println(result.ToString())
+			if view != nil {
+				view.updateAnalysis(result.ToString())
+			}
+			if analysisLog != nil {
+				_, err := analysisLog.WriteString(result.Json() + "\n")
+				if err != nil {
+					log.Println(err)
+				}
+			}
 		}
 	}
 }
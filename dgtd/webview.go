@@ -0,0 +1,70 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
+)
+
+// webView serves a page showing the position, refreshed every second,
+// with any squares that need putting right highlighted, and the
+// engine's analysis if there is any.
+type webView struct {
+	mutex      sync.Mutex
+	fen        string
+	highlights []chess.Sq
+	analysis   string
+}
+
+func newWebView() *webView {
+	return &webView{fen: godgt.StartingFen}
+}
+
+// update records the position to show, and the squares to highlight.
+func (wv *webView) update(fen string, highlights []chess.Sq) {
+	wv.mutex.Lock()
+	defer wv.mutex.Unlock()
+	wv.fen = fen
+	wv.highlights = highlights
+}
+
+// updateAnalysis records the engine's latest analysis, if any.
+func (wv *webView) updateAnalysis(analysis string) {
+	wv.mutex.Lock()
+	defer wv.mutex.Unlock()
+	wv.analysis = analysis
+}
+
+func (wv *webView) serve(addr string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		page, err := godgt.FSByte(false, "/assets/html/index.html")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write(page)
+	})
+	mux.HandleFunc("/board", func(w http.ResponseWriter, r *http.Request) {
+		wv.mutex.Lock()
+		fen, highlights := wv.fen, wv.highlights
+		wv.mutex.Unlock()
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Cache-Control", "no-cache")
+		godgt.WriteBoardAsPngWithHighlights(fen, 64, highlights, w)
+	})
+	mux.HandleFunc("/analysis", func(w http.ResponseWriter, r *http.Request) {
+		wv.mutex.Lock()
+		analysis := wv.analysis
+		wv.mutex.Unlock()
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Write([]byte(analysis))
+	})
+	log.Println("Serving the board on http://" + addr + "/")
+	log.Fatal(http.ListenAndServe(addr, mux))
+}
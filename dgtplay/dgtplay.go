@@ -0,0 +1,105 @@
+// dgtplay plays a game against a UCI engine over a DGT board. The
+// human plays one colour by moving the pieces; the engine's replies
+// are shown on the clock (and, on a Revelation II, the LEDs), and the
+// human then makes them on the board for it.
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess/engine/uci"
+)
+
+var opts struct {
+	Port string `short:"p" long:"port" description:"Serial port, tcp:host:port or file:recording" default:"/dev/ttyUSB0" env:"DGT_PORT"`
+
+	Engine     string   `short:"e" long:"engine" description:"UCI engine to play against" default:"stockfish"`
+	EngineArgs []string `long:"engine-arg" description:"Argument to pass to the engine (can be repeated)"`
+
+	Color string `short:"c" long:"color" description:"The colour the human plays" choice:"white" choice:"black" default:"white"`
+	Name  string `long:"name" description:"The human's name, for the PGN" default:"Player"`
+
+	Time      time.Duration `short:"t" long:"time" description:"Time for the whole game for each side, e.g. 15m (default: no time control)"`
+	Increment time.Duration `short:"i" long:"increment" description:"Time added to a side's clock after each of its moves"`
+	MoveTime  time.Duration `long:"movetime" description:"Time for the engine to spend on each move (overrides --time for the engine)"`
+	Depth     int           `short:"d" long:"depth" description:"Depth for the engine to search each move to (overrides --movetime)"`
+	Skill     int           `short:"s" long:"skill" description:"Engine skill level, for engines with a \"Skill Level\" option" default:"-1"`
+
+	Resign      int `long:"resign" description:"The engine resigns when its score is this many centipawns or more below zero (0: never)"`
+	ResignMoves int `long:"resign-moves" description:"... for this many moves in a row" default:"3"`
+	DrawScore   int `long:"draw-score" description:"A draw is agreed when the engine's score is within this many centipawns of zero" default:"10"`
+	DrawMoves   int `long:"draw-moves" description:"... for this many moves in a row (0: never)"`
+	DrawAfter   int `long:"draw-after" description:"... but not before this move" default:"40"`
+
+	Pgn      string `long:"pgn" description:"Add the games to this PGN file"`
+	Leds     bool   `long:"leds" description:"Light the engine's moves with the LEDs of a Revelation II"`
+	Gestures string `long:"gestures" description:"Read extra board gestures from this file"`
+}
+
+func main() {
+	_, err := flags.ParseArgs(&opts, os.Args)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	dgtboard, err := godgt.NewDgtBoard(opts.Port)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+
+	engine, err := uci.Run(opts.Engine, opts.EngineArgs, nil)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+	defer engine.Quit()
+
+	var recorder *godgt.GameRecorder
+	if opts.Pgn != "" {
+		tags := godgt.GameTags{
+			Event: "dgtplay",
+			White: opts.Name,
+			Black: filepath.Base(opts.Engine),
+		}
+		if opts.Color == "black" {
+			tags.White, tags.Black = tags.Black, tags.White
+		}
+		recorder, err = godgt.NewGameRecorder(opts.Pgn, tags)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	}
+
+	dgtboard.WriteCommand(godgt.DGT_SEND_RESET)
+	dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
+	dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_NICE)
+
+	player := NewPlayer(dgtboard, engine, recorder)
+	if opts.Gestures != "" {
+		gestures, err := godgt.LoadGestures(opts.Gestures)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		player.mp.Gestures = append(gestures, godgt.DefaultGestures()...)
+	}
+	detector := godgt.NewStablePositionDetector(dgtboard, godgt.DefaultQuietPeriod)
+
+	go dgtboard.ReadLoop()
+	go detector.Run()
+	go player.runClock()
+
+	log.Println("Set up the pieces to start a game.")
+
+	ticker := time.NewTicker(time.Second)
+	for player.step(detector.Messages, ticker.C) {
+	}
+}
@@ -0,0 +1,639 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
+	"github.com/malbrecht/chess/engine"
+	"github.com/malbrecht/chess/engine/uci"
+)
+
+// The score given to a mate, less the number of moves to it.
+const mateScore = 100000
+
+// If there's no time control or fixed search, the engine gets this
+// long for each move.
+const defaultMoveTime = 2 * time.Second
+
+// reply is the result of one search by the engine.
+type reply struct {
+	// The position searched.
+	fen string
+
+	move chess.Move
+	ok   bool
+
+	// The score of the best line, in centipawns from the engine's
+	// point of view.
+	score int
+
+	err error
+}
+
+// Player referees a game between the human at the board and the
+// engine.
+//
+// Moves the human makes for their own side are simply accepted. When
+// it's the engine's turn, its reply is shown, and the next move made
+// on the board has to be that reply; anything else has to be taken
+// back. Taking moves back is allowed at any time, but the engine isn't
+// asked to move again until the human signals "engine move now" (see
+// gestures.go), or makes a move for it.
+type Player struct {
+	dgtboard *godgt.DgtBoard
+	mp       *godgt.MessageProcessor
+	engine   *uci.Engine
+	recorder *godgt.GameRecorder
+
+	human chess.Color
+
+	// The position in the game, and whether it's over.
+	board *chess.Board
+	over  bool
+
+	// The engine's move, waiting to be made on the board, and the
+	// position it's to be made in.
+	expected    *chess.Move
+	expectedFen string
+
+	// Moves made instead of the expected one, which weren't
+	// recorded, and so whose takebacks shouldn't be either.
+	wrongMoves int
+
+	// Set while the engine is searching searchFen. If the position
+	// changes, searchFen is cleared so that the reply is ignored,
+	// and if the engine is needed again straight away, rethink is
+	// set so that it starts again once the old search has stopped.
+	thinking  bool
+	searchFen string
+	rethink   bool
+	replies   chan reply
+
+	// Time left for each side, and when the side to move started
+	// thinking.
+	remaining [2]time.Duration
+	turnStart time.Time
+
+	// The number of moves in a row for which the engine's score
+	// has been bad enough to resign, or close enough to a draw.
+	badScores  int
+	drawScores int
+
+	// Commands for the clock, which are run one at a time.
+	clock chan func() (*godgt.ClockAck, error)
+}
+
+func NewPlayer(dgtboard *godgt.DgtBoard, engine *uci.Engine, recorder *godgt.GameRecorder) *Player {
+	p := &Player{
+		dgtboard: dgtboard,
+		mp:       godgt.NewMessageProcessor(),
+		engine:   engine,
+		recorder: recorder,
+		human:    chess.White,
+		over:     true,
+		replies:  make(chan reply, 1),
+		clock:    make(chan func() (*godgt.ClockAck, error), 16),
+	}
+	if opts.Color == "black" {
+		p.human = chess.Black
+	}
+	if opts.Skill >= 0 {
+		p.setOption("Skill Level", fmt.Sprintf("%d", opts.Skill))
+	}
+	return p
+}
+
+// step waits for the next thing to happen (a message from the board,
+// an event from the MessageProcessor, a reply from the engine, or a
+// tick, to check the human's clock), and deals with it. It returns
+// false once the board has gone away.
+func (p *Player) step(messages <-chan *godgt.Message, tick <-chan time.Time) bool {
+	select {
+	case message, ok := <-messages:
+		if !ok {
+			// The board has gone away; ReadLoop has already
+			// said why.
+			return false
+		}
+		p.mp.ProcessMessage(message)
+	case event := <-p.mp.Events:
+		p.processEvent(event)
+	case reply := <-p.replies:
+		p.processReply(reply)
+	case <-tick:
+		p.checkFlag()
+	}
+	return true
+}
+
+func (p *Player) processEvent(event *godgt.Event) {
+	fmt.Println(event.ToString())
+
+	switch {
+	case event.ResetEvent != nil && event.ResetEvent.Resumed:
+		p.record(event)
+		p.resumeGame(event.ResetEvent.Fen)
+	case event.ResetEvent != nil:
+		p.record(event)
+		p.newGame(event.ResetEvent.Fen)
+	case event.MoveEvent != nil:
+		p.processMove(event)
+	case event.TakebackEvent != nil:
+		p.processTakeback(event)
+	case event.SignalEvent != nil:
+		p.processSignal(event.SignalEvent)
+	case event.ResultEvent != nil:
+		p.record(event)
+		p.stop()
+		p.over = true
+	}
+}
+
+func (p *Player) newGame(fen string) {
+	board, err := chess.ParseFen(fen)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	p.stopThinking()
+	p.board = board
+	p.over = false
+	p.expected = nil
+	p.wrongMoves = 0
+	p.badScores = 0
+	p.drawScores = 0
+	p.remaining = [2]time.Duration{opts.Time, opts.Time}
+	p.turnStart = time.Now()
+	p.clearLeds()
+	p.showClock()
+
+	if board.SideToMove != p.human {
+		p.think()
+	}
+}
+
+// resumeGame carries on from a position that the board reached by
+// moves it couldn't report one by one. The clocks carry on as they
+// were.
+func (p *Player) resumeGame(fen string) {
+	board, err := chess.ParseFen(fen)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	p.stopThinking()
+	p.board = board
+	p.expected = nil
+	p.clearLeds()
+	p.show("")
+
+	if !p.over && board.SideToMove != p.human {
+		p.think()
+	}
+}
+
+func (p *Player) processMove(event *godgt.Event) {
+	moveEvent := event.MoveEvent
+	if p.board == nil || p.over {
+		return
+	}
+
+	if moveEvent.Replaces && p.expected != nil && moveEvent.FenBefore == p.expectedFen {
+		// The engine's move was being made, and paused on the
+		// way; what was taken for a wrong move wasn't one, and
+		// was never recorded, so there's nothing to replace.
+		p.wrongMoves--
+		replaced := *moveEvent
+		replaced.Replaces = false
+		event = godgt.NewMoveEvent(&replaced)
+		moveEvent = event.MoveEvent
+	}
+
+	if p.expected != nil && moveEvent.FenBefore == p.expectedFen {
+		if moveEvent.Move != *p.expected {
+			log.Printf("Please take back %s and play %s\n",
+				moveEvent.San, p.expected.San(p.board))
+			p.wrongMoves++
+			p.board, _ = chess.ParseFen(moveEvent.FenAfter)
+			return
+		}
+		// The engine's move has been made, and the human's time
+		// starts now.
+		p.expected = nil
+		p.turnStart = time.Now()
+		p.clearLeds()
+		p.show("")
+	} else if moveEvent.Replaces {
+		// The human's last move was only the start of this one
+		// (a piece that paused on its way, or a rook moved
+		// before its king), so any reply to it no longer
+		// applies. The time has already been charged.
+		p.stopThinking()
+		p.expected = nil
+		p.clearLeds()
+		p.show("")
+	} else {
+		// A move by the human, for either side.
+		p.stopThinking()
+		p.chargeTime(moveEvent.Side)
+	}
+
+	p.record(event)
+	board, err := chess.ParseFen(moveEvent.FenAfter)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	p.board = board
+	p.showClock()
+
+	if p.checkGameOver() {
+		return
+	}
+	if p.board.SideToMove != p.human && p.expected == nil {
+		p.think()
+	}
+}
+
+func (p *Player) processTakeback(event *godgt.Event) {
+	if p.wrongMoves > 0 {
+		p.wrongMoves--
+	} else {
+		p.record(event)
+	}
+	p.stopThinking()
+
+	board, err := chess.ParseFen(event.TakebackEvent.FenAfter)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	p.board = board
+	p.over = false
+
+	if p.expected != nil {
+		if board.Fen() == p.expectedFen {
+			// Back to where the engine's move can be made.
+			p.showExpected()
+		} else {
+			p.expected = nil
+			p.clearLeds()
+			p.show("")
+		}
+	}
+}
+
+func (p *Player) processSignal(signal *godgt.SignalEvent) {
+	switch signal.Action {
+	case godgt.ActionMoveNow:
+		if p.thinking {
+			// Make do with what it has so far.
+			p.engine.Stop()
+		} else if p.board != nil && !p.over && p.expected == nil &&
+			p.board.SideToMove != p.human {
+			p.think()
+		}
+	case godgt.ActionFlipBoard:
+		p.human = 1 - p.human
+		log.Printf("You are now playing %s\n", colorName(p.human))
+		p.stopThinking()
+		p.expected = nil
+		p.clearLeds()
+		p.show("")
+		if p.board != nil && !p.over && p.board.SideToMove != p.human {
+			p.think()
+		}
+	case godgt.ActionEngineLevel:
+		p.setOption("Skill Level", fmt.Sprintf("%d", signal.Argument))
+	case godgt.ActionSaveGame:
+		if p.recorder != nil {
+			err := p.recorder.Write()
+			if err != nil {
+				log.Println(err)
+			}
+		}
+	}
+}
+
+// think starts the engine searching the current position.
+func (p *Player) think() {
+	if p.over {
+		return
+	}
+	if p.thinking {
+		// Let the old search finish first.
+		p.searchFen = ""
+		p.rethink = true
+		p.engine.Stop()
+		return
+	}
+
+	fen := p.board.Fen()
+	p.thinking = true
+	p.searchFen = fen
+	p.turnStart = time.Now()
+	p.engine.SetPosition(p.board)
+	infos := p.search()
+
+	go func() {
+		r := reply{fen: fen}
+		for info := range infos {
+			if info.Err() != nil {
+				r.err = info.Err()
+			} else if move, ok := info.BestMove(); ok {
+				r.move = move
+				r.ok = true
+			} else if pv := info.Pv(); pv != nil && pv.Rank == 0 {
+				r.score = scoreOf(pv)
+			}
+		}
+		p.replies <- r
+	}()
+}
+
+// search starts a search for as long as the options say.
+func (p *Player) search() <-chan engine.Info {
+	switch {
+	case opts.Depth > 0:
+		return p.engine.SearchDepth(opts.Depth)
+	case opts.MoveTime > 0:
+		return p.engine.SearchTime(opts.MoveTime)
+	case opts.Time > 0:
+		// Plan on the game lasting another 30 moves, and try not
+		// to get into time trouble.
+		left := p.remaining[p.board.SideToMove]
+		budget := left/30 + opts.Increment*3/4
+		if budget > left/2 {
+			budget = left / 2
+		}
+		if budget < 100*time.Millisecond {
+			budget = 100 * time.Millisecond
+		}
+		return p.engine.SearchTime(budget)
+	default:
+		return p.engine.SearchTime(defaultMoveTime)
+	}
+}
+
+// stopThinking abandons the engine's search, if there is one.
+func (p *Player) stopThinking() {
+	p.rethink = false
+	if p.thinking {
+		p.searchFen = ""
+		p.engine.Stop()
+	}
+}
+
+func (p *Player) processReply(r reply) {
+	p.thinking = false
+	if r.fen != p.searchFen || p.board == nil || r.fen != p.board.Fen() {
+		// The position changed while it was thinking.
+		if p.rethink {
+			p.rethink = false
+			p.think()
+		}
+		return
+	}
+	p.searchFen = ""
+
+	if r.err != nil {
+		log.Println("Engine: " + r.err.Error())
+	}
+	if !r.ok {
+		log.Println("The engine didn't come up with a move.")
+		return
+	}
+
+	p.chargeTime(p.board.SideToMove)
+	if p.over || p.adjudicate(r.score) {
+		return
+	}
+
+	p.expected = &r.move
+	p.expectedFen = r.fen
+	log.Printf("Engine plays %s (%+.2f)\n", r.move.San(p.board),
+		float64(r.score)/100.0)
+	p.showExpected()
+}
+
+// showExpected shows the engine's move, for the human to make.
+func (p *Player) showExpected() {
+	p.show(p.expected.San(p.board))
+	if opts.Leds {
+		err := p.dgtboard.LightMove(*p.expected)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// adjudicate resigns or agrees a draw on the engine's behalf, if its
+// score has been bad enough, or close enough to zero, for long enough.
+func (p *Player) adjudicate(score int) bool {
+	if opts.Resign > 0 {
+		if score <= -opts.Resign {
+			p.badScores++
+		} else {
+			p.badScores = 0
+		}
+		if p.badScores >= opts.ResignMoves {
+			p.finish(winner(p.human), "The engine resigns.")
+			return true
+		}
+	}
+
+	if opts.DrawMoves > 0 && p.board.MoveNr >= opts.DrawAfter {
+		if score >= -opts.DrawScore && score <= opts.DrawScore {
+			p.drawScores++
+		} else {
+			p.drawScores = 0
+		}
+		if p.drawScores >= opts.DrawMoves {
+			p.finish(godgt.ResultDraw, "The engine offers a draw, which is agreed.")
+			return true
+		}
+	}
+	return false
+}
+
+// checkGameOver finishes the game if the side to move is mated or
+// stalemated.
+func (p *Player) checkGameOver() bool {
+	check, mate := p.board.IsCheckOrMate()
+	if !mate {
+		return false
+	}
+	if check {
+		p.finish(winner(1-p.board.SideToMove), "Checkmate.")
+	} else {
+		p.finish(godgt.ResultDraw, "Stalemate.")
+	}
+	return true
+}
+
+// chargeTime takes the time since the side started thinking off its
+// clock, and adds the increment.
+func (p *Player) chargeTime(side chess.Color) {
+	now := time.Now()
+	if opts.Time > 0 && !p.over {
+		p.remaining[side] -= now.Sub(p.turnStart)
+		if p.remaining[side] < 0 {
+			p.finish(winner(1-side), colorName(side)+" has run out of time.")
+		} else {
+			p.remaining[side] += opts.Increment
+		}
+	}
+	p.turnStart = now
+}
+
+// checkFlag is called every so often, to see whether the human has run
+// out of time. (The engine's time is only checked after each move,
+// since it's trusted to keep an eye on its own clock.) The human's
+// clock only runs once it's their turn, and the engine's move, if
+// there was one, has been made on the board.
+func (p *Player) checkFlag() {
+	if opts.Time == 0 || p.over || p.board == nil {
+		return
+	}
+	if p.board.SideToMove != p.human || p.expected != nil {
+		return
+	}
+	if time.Since(p.turnStart) > p.remaining[p.human] {
+		p.remaining[p.human] = 0
+		p.finish(winner(1-p.human), colorName(p.human)+" has run out of time.")
+	}
+}
+
+func (p *Player) finish(result string, reason string) {
+	log.Println(reason + " " + result)
+	p.stop()
+	p.over = true
+	p.expected = nil
+	p.clearLeds()
+	p.show(result)
+	if p.recorder != nil {
+		err := p.recorder.FinishGame(result)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// stop stops the engine and the clock.
+func (p *Player) stop() {
+	p.stopThinking()
+	if p.mp.Clock != nil && opts.Time > 0 {
+		white, black := p.remaining[chess.White], p.remaining[chess.Black]
+		p.sendClock(func() (*godgt.ClockAck, error) {
+			return p.dgtboard.SetAndRun(white, black, godgt.ClockPaused, false)
+		})
+	}
+}
+
+func (p *Player) record(event *godgt.Event) {
+	if p.recorder == nil {
+		return
+	}
+	err := p.recorder.ProcessEvent(event)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+func (p *Player) setOption(name string, value string) {
+	option, ok := p.engine.Options()[name]
+	if !ok {
+		log.Printf("The engine has no %s option.\n", name)
+		return
+	}
+	option.Set(value)
+}
+
+// show shows text on the clock; an empty text goes back to the times.
+func (p *Player) show(text string) {
+	if p.mp.Clock == nil {
+		// No clock to show it on.
+		return
+	}
+	p.sendClock(func() (*godgt.ClockAck, error) {
+		if text == "" {
+			return p.dgtboard.EndDisplay()
+		}
+		return p.dgtboard.ShowText(text, true)
+	})
+}
+
+// showClock sets the clock to the time each side has left, and starts
+// it for the side to move.
+func (p *Player) showClock() {
+	if p.mp.Clock == nil || opts.Time == 0 || p.over {
+		return
+	}
+	run := godgt.ClockWhiteRuns
+	if p.board.SideToMove == chess.Black {
+		run = godgt.ClockBlackRuns
+	}
+	white, black := p.remaining[chess.White], p.remaining[chess.Black]
+	p.sendClock(func() (*godgt.ClockAck, error) {
+		return p.dgtboard.SetAndRun(white, black, run, false)
+	})
+}
+
+func (p *Player) sendClock(command func() (*godgt.ClockAck, error)) {
+	select {
+	case p.clock <- command:
+	default:
+		log.Println("Clock: too many commands waiting; dropping one")
+	}
+}
+
+// runClock sends commands to the clock one at a time, since each has
+// to wait for the clock to acknowledge it.
+func (p *Player) runClock() {
+	for command := range p.clock {
+		_, err := command()
+		if err != nil {
+			log.Println("Clock: " + err.Error())
+		}
+	}
+}
+
+func (p *Player) clearLeds() {
+	if !opts.Leds {
+		return
+	}
+	err := p.dgtboard.ClearLeds()
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// scoreOf turns the score of a line into centipawns, counting a mate
+// as a very large score.
+func scoreOf(pv *engine.Pv) int {
+	switch {
+	case pv.Mate > 0:
+		return mateScore - pv.Mate
+	case pv.Mate < 0:
+		return -mateScore - pv.Mate
+	default:
+		return pv.Score
+	}
+}
+
+func winner(color chess.Color) string {
+	if color == chess.White {
+		return godgt.ResultWhiteWins
+	}
+	return godgt.ResultBlackWins
+}
+
+func colorName(color chess.Color) string {
+	if color == chess.White {
+		return "White"
+	}
+	return "Black"
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
+	"github.com/malbrecht/chess/engine/uci"
+)
+
+// TestFlag plays against ucistub on the simulator, with a second on
+// the human's clock, and checks that the human only runs out of time
+// while it's their turn: not while the engine's move is waiting to be
+// made on the board.
+func TestFlag(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go tool is needed to build ucistub")
+	}
+	stub := filepath.Join(t.TempDir(), "ucistub")
+	output, err := exec.Command(goTool, "build", "-o", stub, "../ucistub").CombinedOutput()
+	if err != nil {
+		t.Fatalf("building ucistub: %v\n%s", err, output)
+	}
+	engine, err := uci.Run(stub, []string{"--delay", "10ms"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer engine.Quit()
+
+	saved := opts
+	defer func() { opts = saved }()
+	opts.Color = "white"
+	opts.Skill = -1
+	opts.Time = time.Second
+
+	boardEnd, simEnd := net.Pipe()
+	sim := godgt.NewSimulator(simEnd)
+	go sim.Run()
+	defer simEnd.Close()
+
+	dgtboard := godgt.NewDgtBoardFromTransport(boardEnd)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dgtboard.Run(ctx)
+	detector := godgt.NewStablePositionDetector(dgtboard, 50*time.Millisecond)
+	go detector.Run()
+
+	player := NewPlayer(dgtboard, engine, nil)
+	dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_BRD)
+	dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	runUntil := func(what string, done func() bool) {
+		timeout := time.Now().Add(5 * time.Second)
+		for !done() {
+			if time.Now().After(timeout) {
+				t.Fatalf("timed out waiting for %s", what)
+			}
+			player.step(detector.Messages, ticker.C)
+		}
+	}
+	runFor := func(d time.Duration) {
+		until := time.Now().Add(d)
+		runUntil("time to pass", func() bool { return time.Now().After(until) })
+	}
+
+	runUntil("the game to start", func() bool {
+		return player.board != nil && !player.over
+	})
+
+	err = sim.Do("move e2 e4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	runUntil("the engine's reply", func() bool { return player.expected != nil })
+
+	// Longer than the human has left, but the engine's move hasn't
+	// been made yet, so the human's clock isn't running.
+	runFor(1500 * time.Millisecond)
+	if player.over {
+		t.Fatal("the human lost on time while waiting for the engine's move to be made")
+	}
+
+	reply := *player.expected
+	err = sim.Do(fmt.Sprintf("move %s %s", reply.From, reply.To))
+	if err != nil {
+		t.Fatal(err)
+	}
+	runUntil("the engine's move to be made", func() bool {
+		return player.expected == nil && player.board.SideToMove == chess.White
+	})
+	if player.over {
+		t.Fatal("the human lost on time as soon as the engine's move was made")
+	}
+
+	runUntil("the human to lose on time", func() bool { return player.over })
+	if player.remaining[chess.White] != 0 {
+		t.Fatalf("game over, but White has %s left", player.remaining[chess.White])
+	}
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
+)
+
+var opts struct {
+	Script string `short:"s" long:"script" description:"Script of physical actions to perform ('-' for stdin)"`
+
+	Pause time.Duration `short:"d" long:"pause" description:"Pause between scripted actions" default:"500ms"`
+
+	Listen string `short:"l" long:"listen" description:"Listen on a TCP address (e.g. localhost:2000) instead of a pseudo-terminal"`
+
+	Fen string `short:"f" long:"fen" description:"Starting position"`
+
+	Serial string `long:"serial" description:"Serial number (5 digits), to tell several simulated boards apart"`
+
+	Boards int `long:"boards" description:"Simulate this many boards chained together in bus mode, with serial numbers counting up from --serial (the script drives the first)" default:"1"`
+}
+
+func main() {
+	_, err := flags.ParseArgs(&opts, os.Args)
+
+	if err != nil {
+		os.Exit(1)
+	}
+
+	if opts.Listen != "" {
+		listenTCP()
+	} else {
+		listenPty()
+	}
+}
+
+func listenPty() {
+	pty, err := godgt.OpenPty()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pty.Close()
+
+	fmt.Printf("Simulated DGT board on %s\n", pty.SlaveName)
+	fmt.Printf("e.g.: dgtd %s\n", pty.SlaveName)
+
+	err = serve(pty)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func listenTCP() {
+	listener, err := net.Listen("tcp", opts.Listen)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("Simulated DGT board on tcp:%s\n", listener.Addr())
+	fmt.Printf("e.g.: dgtd tcp:%s\n", listener.Addr())
+
+	// Serve one connection at a time, just like a real board.
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Connection from %s\n", conn.RemoteAddr())
+		err = serve(conn)
+		if err != nil && err != io.EOF {
+			log.Println(err)
+		}
+		conn.Close()
+	}
+}
+
+// serve runs the simulated board, or boards, until the connection is
+// closed.
+func serve(port godgt.Transport) error {
+	if opts.Boards <= 1 {
+		sim := newSimulator(port, opts.Serial)
+		go runScript(sim)
+		return sim.Run()
+	}
+	return serveBus(port)
+}
+
+// serveBus chains several simulated boards together on one line: each
+// command is heard by all of them, and whatever any of them says is
+// passed back.
+func serveBus(port godgt.Transport) error {
+	first := 1
+	if opts.Serial != "" {
+		var err error
+		first, err = strconv.Atoi(opts.Serial)
+		if err != nil {
+			return err
+		}
+	}
+
+	var writeMutex sync.Mutex
+	var boards []godgt.Transport
+	for i := 0; i < opts.Boards; i++ {
+		line, board := godgt.NewPipeTransport()
+		boards = append(boards, line)
+		sim := newSimulator(board, strconv.Itoa(first+i))
+		log.Printf("Board %d: serial number %s\n", i+1, sim.SerialNumber)
+		if i == 0 {
+			go runScript(sim)
+		}
+		go func() {
+			err := sim.Run()
+			if err != nil && err != io.EOF && err != io.ErrClosedPipe {
+				log.Println(err)
+			}
+		}()
+		go func() {
+			// Each message is written in one go, so as long as
+			// they're passed on one at a time, they can't get
+			// mixed up.
+			buf := make([]byte, 1024)
+			for {
+				n, err := line.Read(buf)
+				if err != nil {
+					return
+				}
+				writeMutex.Lock()
+				port.Write(buf[:n])
+				writeMutex.Unlock()
+			}
+		}()
+	}
+	defer func() {
+		for _, line := range boards {
+			line.Close()
+		}
+	}()
+
+	buf := make([]byte, 1024)
+	for {
+		n, err := port.Read(buf)
+		if n > 0 {
+			for _, line := range boards {
+				line.Write(buf[:n])
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func newSimulator(port godgt.Transport, serial string) *godgt.Simulator {
+	sim := godgt.NewSimulator(port)
+	if serial != "" {
+		sim.SerialNumber = fmt.Sprintf("%05s", serial)
+		sim.LongSerialNumber = fmt.Sprintf("%010s", serial)
+	}
+	if opts.Fen != "" {
+		board, err := chess.ParseFen(opts.Fen)
+		if err != nil {
+			log.Fatal(err)
+		}
+		sim.SetPosition(board)
+	}
+	return sim
+}
+
+func runScript(sim *godgt.Simulator) {
+	if opts.Script == "" {
+		return
+	}
+	var r io.Reader
+	if opts.Script == "-" {
+		r = os.Stdin
+	} else {
+		fh, err := os.Open(opts.Script)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer fh.Close()
+		r = fh
+	}
+	err := sim.RunScript(r, opts.Pause)
+	if err != nil {
+		log.Println(err)
+	}
+	log.Println("Script finished.")
+}
@@ -0,0 +1,169 @@
+package godgt
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DraughtsPiece is a piece on a draughts board.
+type DraughtsPiece byte
+
+const (
+	DraughtsEmpty DraughtsPiece = iota
+	DraughtsWhiteMan
+	DraughtsBlackMan
+	DraughtsWhiteKing
+	DraughtsBlackKing
+)
+
+// DraughtsPieceLetters are the letters used for the pieces in
+// diagrams: lower case for men, upper case for kings.
+const DraughtsPieceLetters = ".wbWB"
+
+func (dp DraughtsPiece) IsWhite() bool {
+	return dp == DraughtsWhiteMan || dp == DraughtsWhiteKing
+}
+
+func (dp DraughtsPiece) IsKing() bool {
+	return dp == DraughtsWhiteKing || dp == DraughtsBlackKing
+}
+
+// DraughtsPosition is a position on a 10x10 draughts board.
+//
+// The fields are numbered 0-99 as the board numbers them: row by row,
+// starting from the top left as seen by White, just like the 64 fields
+// of a chess board. The playable squares are numbered 1-50 as in PDN,
+// also in reading order, so that White's men start on 31-50. Normally
+// the game is played on the dark squares, with the top left field
+// light; if LightSquares is set, it's played on the light ones.
+type DraughtsPosition struct {
+	// Every field on the board. There shouldn't be any pieces on the
+	// squares that aren't played on, but the board will report them
+	// if there are.
+	Fields [100]DraughtsPiece
+
+	LightSquares bool
+
+	// The board can't tell whose move it is, so this is White unless
+	// the application knows better.
+	BlackToMove bool
+}
+
+// DraughtsSquare returns the number (1-50) of the square on a field
+// (0-99), or 0 if the field isn't one of the squares played on.
+func DraughtsSquare(field int, lightSquares bool) int {
+	row := field / 10
+	column := field % 10
+	dark := (row+column)%2 == 1
+	if dark == lightSquares {
+		return 0
+	}
+	return row*5 + column/2 + 1
+}
+
+// DraughtsField is the inverse of DraughtsSquare.
+func DraughtsField(square int, lightSquares bool) int {
+	row := (square - 1) / 5
+	column := (square - 1) % 5 * 2
+	// The top left field is light, so the dark squares start one
+	// field in on even rows, and the light ones on odd rows.
+	if (row%2 == 0) != lightSquares {
+		column++
+	}
+	return row*10 + column
+}
+
+// Square returns the piece on a numbered square (1-50).
+func (dp *DraughtsPosition) Square(square int) DraughtsPiece {
+	return dp.Fields[DraughtsField(square, dp.LightSquares)]
+}
+
+// Fen returns the position in the FEN notation used by PDN, e.g.
+// "W:W31,32,K45:B1,2,3", with the side to move and then the squares of
+// each side's pieces, the kings marked with a K.
+func (dp *DraughtsPosition) Fen() string {
+	var white, black []string
+	for square := 1; square <= 50; square++ {
+		piece := dp.Square(square)
+		if piece == DraughtsEmpty {
+			continue
+		}
+		text := fmt.Sprintf("%d", square)
+		if piece.IsKing() {
+			text = "K" + text
+		}
+		if piece.IsWhite() {
+			white = append(white, text)
+		} else {
+			black = append(black, text)
+		}
+	}
+	toMove := "W"
+	if dp.BlackToMove {
+		toMove = "B"
+	}
+	return fmt.Sprintf("%s:W%s:B%s", toMove,
+		strings.Join(white, ","), strings.Join(black, ","))
+}
+
+// Rows returns the board as ten strings of DraughtsPieceLetters, from
+// the top, as SimpleBoardFromFen does for chess.
+func (dp *DraughtsPosition) Rows() []string {
+	var rows []string
+	for row := 0; row < 10; row++ {
+		var letters []byte
+		for column := 0; column < 10; column++ {
+			letters = append(letters, DraughtsPieceLetters[dp.Fields[row*10+column]])
+		}
+		rows = append(rows, string(letters))
+	}
+	return rows
+}
+
+func (dp *DraughtsPosition) ToString() string {
+	return dp.Fen()
+}
+
+// DraughtsBoardUpdate contains a full position from a draughts board,
+// from either a 100 field or a 50 square dump.
+type DraughtsBoardUpdate struct {
+	Position *DraughtsPosition
+}
+
+func NewDraughtsBoardUpdate(position *DraughtsPosition) *DraughtsBoardUpdate {
+	return &DraughtsBoardUpdate{
+		Position: position,
+	}
+}
+
+func (du *DraughtsBoardUpdate) ToString() string {
+	return du.Position.ToString()
+}
+
+// DraughtsFieldUpdate is a field update from a draughts board: a piece
+// (or DraughtsEmpty) arriving on a field.
+type DraughtsFieldUpdate struct {
+	Field int
+
+	// The number of the square, or 0 if the field isn't one of the
+	// squares played on.
+	Square int
+
+	Piece DraughtsPiece
+}
+
+func NewDraughtsFieldUpdate(field int, square int, piece DraughtsPiece) *DraughtsFieldUpdate {
+	return &DraughtsFieldUpdate{
+		Field:  field,
+		Square: square,
+		Piece:  piece,
+	}
+}
+
+func (dfu *DraughtsFieldUpdate) ToString() string {
+	letter := DraughtsPieceLetters[dfu.Piece]
+	if dfu.Square == 0 {
+		return fmt.Sprintf("%c@field %d", letter, dfu.Field)
+	}
+	return fmt.Sprintf("%c@%d", letter, dfu.Square)
+}
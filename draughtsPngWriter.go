@@ -0,0 +1,82 @@
+package godgt
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"log"
+	"os"
+)
+
+// GetDraughtsImageName returns the name of the image for a draughts
+// piece, as found in assets/images/draughts.
+func GetDraughtsImageName(piece DraughtsPiece) string {
+	switch piece {
+	case DraughtsWhiteMan:
+		return "WM"
+	case DraughtsBlackMan:
+		return "BM"
+	case DraughtsWhiteKing:
+		return "WK"
+	case DraughtsBlackKing:
+		return "BK"
+	default:
+		return ""
+	}
+}
+
+func WriteDraughtsPng(position *DraughtsPosition, size int, filename string) {
+	w, err := os.Create(filename)
+	if err != nil {
+		log.Fatal("Failed to open file for writing.")
+	}
+	defer w.Close()
+	WriteDraughtsBoardAsPng(position, size, w)
+}
+
+// WriteDraughtsBoardAsPng is WriteBoardAsPng for a draughts position.
+// The dark squares are the same as on the chess board, and the pieces
+// have a transparent background, so they're drawn on top.
+func WriteDraughtsBoardAsPng(position *DraughtsPosition, size int, w io.Writer) {
+	fs := FS(false)
+	output := image.NewRGBA(image.Rect(0, 0, size*10, size*10))
+	white := color.RGBA{255, 255, 255, 255}
+	draw.Draw(output, output.Bounds(), &image.Uniform{white},
+		image.ZP, draw.Src)
+
+	for field, piece := range position.Fields {
+		iRow := field / 10
+		iCol := field % 10
+		var imagePaths []string
+		if (iCol+iRow)%2 == 1 {
+			imagePaths = append(imagePaths,
+				fmt.Sprintf("/assets/images/%d/EMPTYD.png", size))
+		}
+		if imageName := GetDraughtsImageName(piece); imageName != "" {
+			imagePaths = append(imagePaths,
+				fmt.Sprintf("/assets/images/draughts/%d/%s.png", size, imageName))
+		}
+		for _, imagePath := range imagePaths {
+			file, err := fs.Open(imagePath)
+			if err != nil {
+				log.Fatal("Error opening " + imagePath +
+					": " + err.Error())
+			}
+			oneImage, err := png.Decode(file)
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			x := size * iCol
+			y := size * iRow
+			r := image.Rectangle{
+				image.Pt(x, y),
+				image.Pt(x+size, y+size)}
+			draw.Draw(output, r, oneImage, image.ZP, draw.Over)
+		}
+	}
+	png.Encode(w, output)
+}
@@ -0,0 +1,143 @@
+package godgt
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// EEEventType says what kind of record an EEEvent came from.
+type EEEventType int
+
+const (
+	// A piece was placed on, or lifted from, a square.
+	EEFieldChange EEEventType = iota
+	// The time on the clock of the player on the left.
+	EELeftTime
+	// The time on the clock of the player on the right.
+	EERightTime
+	// The board was switched on; the position it saw is in Board.
+	EEPowerUp
+	// Any of the other single-byte tags (EE_BEGINPOS, EE_DOWNLOADED,
+	// etc.); the tag itself is in Tag.
+	EEMarker
+	// The end of the storage.
+	EEEndOfFile
+)
+
+// EEEvent is a single record from the board's move storage.
+type EEEvent struct {
+	Type EEEventType
+
+	// The tag byte that started the record.
+	Tag byte
+
+	// Set for EEFieldChange.
+	FieldUpdate *FieldUpdate
+
+	// Set for EEPowerUp.
+	Board *chess.Board
+
+	// Set for EELeftTime and EERightTime.
+	Time time.Duration
+}
+
+func (ev *EEEvent) ToString() string {
+	switch ev.Type {
+	case EEFieldChange:
+		return "Field change: " + ev.FieldUpdate.ToString()
+	case EELeftTime:
+		return "Left time: " + FormatClockTime(ev.Time)
+	case EERightTime:
+		return "Right time: " + FormatClockTime(ev.Time)
+	case EEPowerUp:
+		return "Power up: " + ev.Board.Fen()
+	case EEEndOfFile:
+		return "End of storage"
+	default:
+		return "Marker: " + eeTagName(ev.Tag)
+	}
+}
+
+func eeTagName(tag byte) string {
+	switch tag {
+	case EE_FOURROWS:
+		return "four rows"
+	case EE_EMPTYBOARD:
+		return "empty board"
+	case EE_DOWNLOADED:
+		return "downloaded"
+	case EE_BEGINPOS:
+		return "start position"
+	case EE_BEGINPOS_ROT:
+		return "start position (rotated)"
+	case EE_START_TAG:
+		return "start of storage"
+	case EE_WATCHDOG_ACTION:
+		return "watchdog reset"
+	default:
+		return fmt.Sprintf("0x%02x", tag)
+	}
+}
+
+// EEMoves is the decoded contents of the board's move storage, in the
+// order in which the board recorded it (oldest first).
+type EEMoves struct {
+	Events []*EEEvent
+
+	// The storage doesn't say where the clock was, so this is copied
+	// from the DgtBoard, as for a TimeUpdate.
+	WhiteOnRight bool
+}
+
+func NewEEMoves(events []*EEEvent, whiteOnRight bool) *EEMoves {
+	return &EEMoves{
+		Events:       events,
+		WhiteOnRight: whiteOnRight,
+	}
+}
+
+func (ee *EEMoves) ToString() string {
+	var lines []string
+	for _, event := range ee.Events {
+		lines = append(lines, event.ToString())
+	}
+	return fmt.Sprintf("EE moves (%d events):\n%s", len(ee.Events),
+		strings.Join(lines, "\n"))
+}
+
+// Messages turns the stored events back into the messages that the
+// board would have sent had it been connected at the time, so that
+// they can be fed to a MessageProcessor. Power-ups become board
+// updates, field changes become field updates, and clock times become
+// time updates carrying the most recent time for both players. The
+// markers have no message equivalent, and are left out.
+func (ee *EEMoves) Messages() []*Message {
+	var messages []*Message
+	var left, right time.Duration
+	for _, event := range ee.Events {
+		switch event.Type {
+		case EEPowerUp:
+			messages = append(messages,
+				NewBoardUpdateMessage(NewBoardUpdate(event.Board)))
+		case EEFieldChange:
+			messages = append(messages,
+				NewFieldUpdateMessage(event.FieldUpdate))
+		case EELeftTime, EERightTime:
+			if event.Type == EELeftTime {
+				left = event.Time
+			} else {
+				right = event.Time
+			}
+			timeUpdate := &TimeUpdate{
+				Left:         PlayerClock{Remaining: left},
+				Right:        PlayerClock{Remaining: right},
+				WhiteOnRight: ee.WhiteOnRight,
+			}
+			messages = append(messages, NewTimeUpdateMessage(timeUpdate))
+		}
+	}
+	return messages
+}
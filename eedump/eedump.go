@@ -0,0 +1,124 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kgigitdev/godgt"
+)
+
+var opts struct {
+	Port string `short:"p" long:"port" description:"Serial port, tcp:host:port or file:recording" default:"/dev/ttyUSB0" env:"DGT_PORT"`
+
+	Events bool `short:"e" long:"events" description:"Print every stored event"`
+
+	Timeout time.Duration `short:"t" long:"timeout" description:"How long to wait for the board to send its storage" default:"10s"`
+}
+
+// eedump downloads the history that a board records in its EEPROM
+// while it isn't connected to anything, and replays it to rebuild the
+// game.
+func main() {
+	_, err := flags.ParseArgs(&opts, os.Args)
+
+	if err != nil {
+		os.Exit(1)
+	}
+
+	dgtboard, err := godgt.NewDgtBoard(opts.Port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Stay in idle mode, so that nothing else arrives while the
+	// storage is being sent.
+	dgtboard.WriteCommand(godgt.DGT_SEND_RESET)
+	dgtboard.WriteCommand(godgt.DGT_SEND_EE_MOVES)
+
+	go dgtboard.ReadLoop()
+
+	eeMoves := waitForEEMoves(dgtboard)
+	if eeMoves == nil {
+		if err := dgtboard.Err(); err != nil {
+			log.Fatal(err)
+		}
+		log.Fatal("Timed out waiting for the board's storage.")
+	}
+	dgtboard.Close()
+
+	if opts.Events {
+		for _, event := range eeMoves.Events {
+			fmt.Println(event.ToString())
+		}
+	}
+
+	// The replay is done in one go, and moves are only recognised
+	// where the pieces settle, so there has to be room for every
+	// event it could produce.
+	mp := godgt.NewMessageProcessor()
+	mp.Events = make(chan *godgt.Event, 4*len(eeMoves.Events)+16)
+	mp.ProcessMessage(godgt.NewEEMovesMessage(eeMoves))
+	events := drainEvents(mp)
+
+	if mp.Board == nil {
+		log.Fatal("The storage contains no position to start from.")
+	}
+	writeGames(events)
+}
+
+func drainEvents(mp *godgt.MessageProcessor) []*godgt.Event {
+	var events []*godgt.Event
+	for {
+		select {
+		case event := <-mp.Events:
+			events = append(events, event)
+		default:
+			return events
+		}
+	}
+}
+
+// writeGames prints the games recognised during the replay as PGN.
+func writeGames(events []*godgt.Event) {
+	tags := godgt.GameTags{
+		Event: "DGT board storage",
+	}
+	var games []*godgt.Game
+	var game *godgt.Game
+	for _, event := range events {
+		if event.ResetEvent != nil {
+			game = godgt.NewGame(tags, event.ResetEvent.Fen)
+			game.Chess960 = event.ResetEvent.Chess960
+			games = append(games, game)
+		} else if event.MoveEvent != nil && game != nil {
+			game.AddMove(event.MoveEvent)
+		} else if event.ResultEvent != nil && game != nil {
+			game.Result = event.ResultEvent.Result
+		}
+	}
+	for _, game := range games {
+		if game.HasMoves() {
+			game.WritePgn(os.Stdout)
+		}
+	}
+}
+
+func waitForEEMoves(dgtboard *godgt.DgtBoard) *godgt.EEMoves {
+	timeout := time.After(opts.Timeout)
+	for {
+		select {
+		case message, ok := <-dgtboard.MessagesFromBoard:
+			if !ok {
+				return nil
+			}
+			if message.EEMoves != nil {
+				return message.EEMoves
+			}
+		case <-timeout:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package godgt
+
+import "time"
+
+// encodeMessage frames some data as a board-to-PC message, which is
+// the reverse of what parseBytes does. The length in the header
+// includes the three header bytes themselves, and is split into two
+// 7-bit halves so that only the first byte has its MSB set.
+func encodeMessage(messageId byte, data []byte) []byte {
+	length := len(data) + 3
+	message := []byte{
+		MESSAGE_BIT | messageId,
+		byte((length >> 7) & MESSAGE_MASK),
+		byte(length & MESSAGE_MASK),
+	}
+	return append(message, data...)
+}
+
+// toBcd encodes a number from 0-99 as two BCD digits, as used for the
+// minutes and seconds of the clock times.
+func toBcd(n int) byte {
+	return byte((n/10)<<4 | (n % 10))
+}
+
+// fromBcd decodes two BCD digits.
+func fromBcd(b byte) int {
+	return int(b>>4)*10 + int(b&0x0f)
+}
+
+// clockTime converts hours and BCD minutes and seconds into a
+// duration.
+func clockTime(hours byte, minutes byte, seconds byte) time.Duration {
+	return time.Duration(hours&0x0f)*time.Hour +
+		time.Duration(fromBcd(minutes))*time.Minute +
+		time.Duration(fromBcd(seconds))*time.Second
+}
+
+// encodeClockTime splits a duration into hours and BCD minutes and
+// seconds.
+func encodeClockTime(d time.Duration) (byte, byte, byte) {
+	if d < 0 {
+		d = 0
+	}
+	seconds := int(d / time.Second)
+	hours := seconds / 3600
+	minutes := (seconds / 60) % 60
+	seconds = seconds % 60
+	if hours > 9 {
+		hours = 9
+	}
+	return byte(hours), toBcd(minutes), toBcd(seconds)
+}
@@ -0,0 +1,254 @@
+package godgt
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// MoveEvent is a move recognised by the MessageProcessor.
+type MoveEvent struct {
+	Move chess.Move
+	San  string
+
+	// The position before and after the move.
+	FenBefore string
+	FenAfter  string
+
+	// The side that made the move, and the number of the move (in
+	// the PGN sense; White's and Black's moves share a number).
+	Side       chess.Color
+	MoveNumber int
+
+	// Set if the move replaces the one reported just before it,
+	// which turned out to be only the start of this one: a rook
+	// moved before its king, when castling, or a piece that paused
+	// on its way to another square.
+	Replaces bool
+
+	// The most recent clock reading when the move was made, or nil
+	// if there's no clock.
+	Clock *TimeUpdate
+
+	Time time.Time
+}
+
+func (me *MoveEvent) ToString() string {
+	if me.Side == chess.White {
+		return fmt.Sprintf("Move: %d. %s", me.MoveNumber, me.San)
+	}
+	return fmt.Sprintf("Move: %d... %s", me.MoveNumber, me.San)
+}
+
+// ResetEvent says that the MessageProcessor has a new position to
+// work from, such as the first board dump it receives. Any moves seen
+// before it belong to a different game.
+type ResetEvent struct {
+	Fen string
+
+	// Set if the new game is to be played under Chess960 rules.
+	Chess960 bool
+
+	// Set if this isn't a new game, but the game in progress
+	// carrying on from a position reached by moves that couldn't be
+	// reported, since more than one order of them leads there.
+	Resumed bool
+
+	Time time.Time
+}
+
+func (re *ResetEvent) ToString() string {
+	if re.Resumed {
+		return "Resumed: " + re.Fen
+	}
+	return "Reset: " + re.Fen
+}
+
+// IllegalMoveEvent is a piece movement that looks like a move, but
+// which isn't legal in the current position.
+type IllegalMoveEvent struct {
+	// The attempted move, in coordinate notation (e.g. "e2e5").
+	Move   string
+	Reason string
+	Fen    string
+	Time   time.Time
+}
+
+func (ime *IllegalMoveEvent) ToString() string {
+	return fmt.Sprintf("Illegal move: %s (%s)", ime.Move, ime.Reason)
+}
+
+// TakebackEvent is a move being taken back: the pieces have been put
+// back to where they were before the move. Going back several moves
+// at once produces one TakebackEvent per move, the most recent first.
+type TakebackEvent struct {
+	Move chess.Move
+	San  string
+
+	// The position before the move was taken back, and the
+	// position the board is now back in.
+	FenBefore string
+	FenAfter  string
+
+	Time time.Time
+}
+
+func (te *TakebackEvent) ToString() string {
+	return "Takeback: " + te.San
+}
+
+// SignalEvent is a special signal made with the pieces, such as
+// lifting a king and putting it back on the same square to set the
+// side to move; see gestures.go. Description says what the signal
+// meant.
+type SignalEvent struct {
+	Square      chess.Sq
+	Piece       chess.Piece
+	Description string
+
+	// The action from the gesture table, and its argument if it has
+	// one.
+	Action   GestureAction
+	Argument int
+
+	Time time.Time
+}
+
+func (se *SignalEvent) ToString() string {
+	return fmt.Sprintf("Signal: %s (%s)", se.Description,
+		fmtpsq(se.Piece, se.Square))
+}
+
+// StateEvent reports a change in how sure the MessageProcessor is of
+// the position on the board; see InferenceState.
+type StateEvent struct {
+	State InferenceState
+
+	// The last confirmed position.
+	Fen string
+
+	// If the position has diverged, what needs doing to put the
+	// pieces back to Fen.
+	Diff PositionDiff
+
+	Time time.Time
+}
+
+func (se *StateEvent) ToString() string {
+	if len(se.Diff) > 0 {
+		return "Position " + se.State.String() + ": " + se.Diff.ToString()
+	}
+	return "Position " + se.State.String()
+}
+
+// ResultEvent says that the game is over, because someone has put one
+// of the magic result pieces on the board.
+type ResultEvent struct {
+	// One of ResultDraw, ResultWhiteWins or ResultBlackWins.
+	Result string
+
+	// The final position of the game.
+	Fen  string
+	Time time.Time
+}
+
+func (re *ResultEvent) ToString() string {
+	return "Result: " + re.Result
+}
+
+// SetupEvent says that the pieces have been set up in a position which
+// isn't the start of a game, and the MessageProcessor is waiting for
+// the players to confirm it (see setup.go).
+type SetupEvent struct {
+	Fen  string
+	Time time.Time
+}
+
+func (se *SetupEvent) ToString() string {
+	return "Setup: " + se.Fen
+}
+
+// Event multiplexes the different things that a MessageProcessor can
+// report onto a single channel, in the same way that Message does for
+// the board.
+type Event struct {
+	MoveEvent        *MoveEvent
+	ResetEvent       *ResetEvent
+	IllegalMoveEvent *IllegalMoveEvent
+	TakebackEvent    *TakebackEvent
+	SignalEvent      *SignalEvent
+	StateEvent       *StateEvent
+	ResultEvent      *ResultEvent
+	SetupEvent       *SetupEvent
+}
+
+func (e *Event) ToString() string {
+	if e.MoveEvent != nil {
+		return e.MoveEvent.ToString()
+	} else if e.ResetEvent != nil {
+		return e.ResetEvent.ToString()
+	} else if e.IllegalMoveEvent != nil {
+		return e.IllegalMoveEvent.ToString()
+	} else if e.TakebackEvent != nil {
+		return e.TakebackEvent.ToString()
+	} else if e.SignalEvent != nil {
+		return e.SignalEvent.ToString()
+	} else if e.StateEvent != nil {
+		return e.StateEvent.ToString()
+	} else if e.ResultEvent != nil {
+		return e.ResultEvent.ToString()
+	} else if e.SetupEvent != nil {
+		return e.SetupEvent.ToString()
+	} else {
+		return ""
+	}
+}
+
+func NewMoveEvent(moveEvent *MoveEvent) *Event {
+	return &Event{
+		MoveEvent: moveEvent,
+	}
+}
+
+func NewResetEvent(resetEvent *ResetEvent) *Event {
+	return &Event{
+		ResetEvent: resetEvent,
+	}
+}
+
+func NewIllegalMoveEvent(illegalMoveEvent *IllegalMoveEvent) *Event {
+	return &Event{
+		IllegalMoveEvent: illegalMoveEvent,
+	}
+}
+
+func NewTakebackEvent(takebackEvent *TakebackEvent) *Event {
+	return &Event{
+		TakebackEvent: takebackEvent,
+	}
+}
+
+func NewSignalEvent(signalEvent *SignalEvent) *Event {
+	return &Event{
+		SignalEvent: signalEvent,
+	}
+}
+
+func NewStateEvent(stateEvent *StateEvent) *Event {
+	return &Event{
+		StateEvent: stateEvent,
+	}
+}
+
+func NewResultEvent(resultEvent *ResultEvent) *Event {
+	return &Event{
+		ResultEvent: resultEvent,
+	}
+}
+
+func NewSetupEvent(setupEvent *SetupEvent) *Event {
+	return &Event{
+		SetupEvent: setupEvent,
+	}
+}
@@ -0,0 +1,208 @@
+package godgt
+
+import "sync"
+
+// Frame is a single complete message from the board: the message ID
+// (with MESSAGE_BIT masked off) and the data following the three
+// header bytes.
+type Frame struct {
+	Id   byte
+	Data []byte
+}
+
+// FrameStats counts what a FrameDecoder has had to do to make sense of
+// its input. On a healthy connection, only Frames should ever go up.
+type FrameStats struct {
+	// The number of complete frames decoded.
+	Frames int
+
+	// The number of bytes thrown away because they couldn't be part
+	// of any valid frame.
+	DroppedBytes int
+
+	// The number of times the decoder lost track of the frame
+	// boundaries and had to hunt for the next header.
+	Resyncs int
+}
+
+// FrameDecoder splits a stream of bytes from the board into frames.
+// Bytes can be written to it in whatever chunks they arrive in; frames
+// are then pulled off with Next.
+//
+// The protocol makes resynchronising fairly easy, since the first
+// byte of a message is the only one with its MSB set. Whenever the
+// decoder finds something that can't be a valid frame (an unknown
+// message ID, a length that doesn't match the one expected for that
+// message, or a new header turning up in the middle of the data) it
+// drops bytes until the next byte with the MSB set, and tries again
+// from there.
+type FrameDecoder struct {
+	mutex  sync.Mutex
+	buffer []byte
+	stats  FrameStats
+
+	// The total length (including the header) of each message we
+	// know about. A length of zero means the message is variable
+	// length.
+	sizes map[byte]int
+}
+
+// NewFrameDecoder returns a decoder for the messages sent by a board
+// in single-board mode.
+func NewFrameDecoder() *FrameDecoder {
+	return &FrameDecoder{
+		sizes: map[byte]int{
+			DGT_NONE:           3,
+			DGT_BOARD_DUMP:     DGT_SIZE_BOARD_DUMP,
+			DGT_BWTIME:         DGT_SIZE_BWTIME,
+			DGT_FIELD_UPDATE:   DGT_SIZE_FIELD_UPDATE,
+			DGT_EE_MOVES:       0,
+			DGT_BUSADRES:       DGT_SIZE_BUSADRES,
+			DGT_SERIALNR:       DGT_SIZE_SERIALNR,
+			DGT_TRADEMARK:      0,
+			DGT_VERSION:        DGT_SIZE_VERSION,
+			DGT_BOARD_DUMP_50B: DGT_SIZE_BOARD_DUMP_50B,
+			DGT_BOARD_DUMP_50W: DGT_SIZE_BOARD_DUMP_50W,
+			// The documentation of the battery status message
+			// disagrees with DGT_SIZE_BATTERY_STATUS, so don't
+			// insist on either.
+			DGT_BATTERY_STATUS: 0,
+			DGT_LONG_SERIALNR:  DGT_SIZE_LONG_SERIALNR,
+		},
+	}
+}
+
+// NewDraughtsFrameDecoder returns a decoder for the messages sent by a
+// 10x10 draughts board, whose board dump has 100 fields.
+func NewDraughtsFrameDecoder() *FrameDecoder {
+	fd := NewFrameDecoder()
+	fd.sizes[DGT_BOARD_DUMP] = DGT_SIZE_BOARD_DUMP_DRAUGHTS
+	return fd
+}
+
+// NewBusFrameDecoder returns a decoder for the messages sent by boards
+// in bus mode. The data of each frame starts with the bus address and
+// ends with the checksum; see DgtBus.
+func NewBusFrameDecoder() *FrameDecoder {
+	return &FrameDecoder{
+		sizes: map[byte]int{
+			DGT_BUS_BRD_DUMP:   DGT_SIZE_BUS_BRD_DUMP,
+			DGT_BUS_CLK_DATA:   DGT_SIZE_BUS_CLK_DATA,
+			DGT_BUS_FROM_START: 0,
+			DGT_BUS_UPDATE:     0,
+			DGT_BUS_PING_REPLY: DGT_SIZE_BUS_PING,
+		},
+	}
+}
+
+// Write appends bytes received from the board. It never fails.
+func (fd *FrameDecoder) Write(p []byte) (int, error) {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	fd.buffer = append(fd.buffer, p...)
+	return len(p), nil
+}
+
+// Stats returns a snapshot of the decoder's counters.
+func (fd *FrameDecoder) Stats() FrameStats {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	return fd.stats
+}
+
+// Next returns the next complete frame, or nil if more data is needed
+// before there is one.
+func (fd *FrameDecoder) Next() *Frame {
+	fd.mutex.Lock()
+	defer fd.mutex.Unlock()
+
+	for {
+		// Anything before the next header byte is junk.
+		skip := 0
+		for skip < len(fd.buffer) && fd.buffer[skip]&MESSAGE_BIT == 0 {
+			skip++
+		}
+		if skip > 0 {
+			fd.drop(skip)
+			fd.stats.Resyncs++
+		}
+
+		if len(fd.buffer) < 3 {
+			// Since a well-formed header is always 3 bytes, if
+			// we haven't read at least three bytes, there's no
+			// point in even trying.
+			return nil
+		}
+
+		if !fd.validHeader() {
+			fd.drop(1)
+			fd.stats.Resyncs++
+			continue
+		}
+
+		// Combine the two length bytes; each contains 7 bits of
+		// the length, which includes the 3 header bytes.
+		length := int(fd.buffer[1])<<7 | int(fd.buffer[2])
+
+		// A header byte turning up inside the data means that
+		// this frame was cut short; throw it away and start again
+		// from the new header.
+		available := len(fd.buffer)
+		if available > length {
+			available = length
+		}
+		truncated := false
+		for i := 3; i < available; i++ {
+			if fd.buffer[i]&MESSAGE_BIT != 0 {
+				fd.drop(i)
+				fd.stats.Resyncs++
+				truncated = true
+				break
+			}
+		}
+		if truncated {
+			continue
+		}
+
+		if len(fd.buffer) < length {
+			// We haven't read the complete message yet.
+			return nil
+		}
+
+		frame := &Frame{
+			Id:   fd.buffer[0] & MESSAGE_MASK,
+			Data: append([]byte{}, fd.buffer[3:length]...),
+		}
+		fd.buffer = fd.buffer[length:]
+		fd.stats.Frames++
+		return frame
+	}
+}
+
+// validHeader checks the first three bytes in the buffer: the message
+// ID must be one we know about, the length bytes must have their MSB
+// clear, and the length must be right for the message.
+func (fd *FrameDecoder) validHeader() bool {
+	expected, known := fd.sizes[fd.buffer[0]&MESSAGE_MASK]
+	if !known {
+		return false
+	}
+	if fd.buffer[1]&MESSAGE_BIT != 0 || fd.buffer[2]&MESSAGE_BIT != 0 {
+		return false
+	}
+	length := int(fd.buffer[1])<<7 | int(fd.buffer[2])
+	if length < 3 {
+		return false
+	}
+	if expected > 0 && length != expected {
+		return false
+	}
+	return true
+}
+
+func (fd *FrameDecoder) drop(n int) {
+	fd.buffer = fd.buffer[n:]
+	fd.stats.DroppedBytes += n
+}
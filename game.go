@@ -0,0 +1,247 @@
+package godgt
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// The FEN of the standard starting position.
+const StartingFen = "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1"
+
+// PGN results.
+const (
+	ResultWhiteWins = "1-0"
+	ResultBlackWins = "0-1"
+	ResultDraw      = "1/2-1/2"
+	ResultUnknown   = "*"
+)
+
+// GameTags are the tags of the PGN Seven Tag Roster, apart from
+// Result, which comes from the game itself, and a few of our own.
+type GameTags struct {
+	Event string
+	Site  string
+	Date  string
+	Round string
+	White string
+	Black string
+
+	// The serial number of the board the game was played on, if
+	// known. It's written as a Board tag.
+	Board string
+}
+
+// GameNode is a move in a game tree. The first child continues the
+// main line; any others are variations.
+type GameNode struct {
+	Move       chess.Move
+	San        string
+	Side       chess.Color
+	MoveNumber int
+
+	// The mover's time left after the move, if there was a clock.
+	Clock    time.Duration
+	HasClock bool
+
+	Parent   *GameNode
+	Children []*GameNode
+}
+
+// Game is a game recorded from the board.
+type Game struct {
+	Tags     GameTags
+	StartFen string
+	Result   string
+
+	// Set for a game played under Chess960 rules.
+	Chess960 bool
+
+	// The root node has no move; it stands for the starting
+	// position.
+	Root *GameNode
+
+	// The last move played (or Root, if none has been).
+	Current *GameNode
+}
+
+func NewGame(tags GameTags, startFen string) *Game {
+	root := &GameNode{}
+	return &Game{
+		Tags:     tags,
+		StartFen: startFen,
+		Result:   ResultUnknown,
+		Root:     root,
+		Current:  root,
+	}
+}
+
+// AddMove plays a move after the current one. If the move has already
+// been played from this position, that line is followed again rather
+// than duplicated. A move that replaces the one before it (see
+// MoveEvent.Replaces) is played in its place instead.
+func (g *Game) AddMove(moveEvent *MoveEvent) *GameNode {
+	if moveEvent.Replaces {
+		g.dropCurrent()
+	}
+
+	for _, child := range g.Current.Children {
+		if child.Move == moveEvent.Move {
+			g.Current = child
+			return child
+		}
+	}
+
+	node := &GameNode{
+		Move:       moveEvent.Move,
+		San:        moveEvent.San,
+		Side:       moveEvent.Side,
+		MoveNumber: moveEvent.MoveNumber,
+		Parent:     g.Current,
+	}
+	if moveEvent.Clock != nil {
+		node.Clock = moveEvent.Clock.Player(moveEvent.Side).Remaining
+		node.HasClock = true
+	}
+	g.Current.Children = append(g.Current.Children, node)
+	g.Current = node
+	return node
+}
+
+// dropCurrent goes back to the position before the current move, and
+// forgets the move, unless something has already been played after
+// it.
+func (g *Game) dropCurrent() {
+	node := g.Current
+	if node.Parent == nil {
+		return
+	}
+	g.Current = node.Parent
+	if len(node.Children) > 0 {
+		return
+	}
+	children := g.Current.Children
+	for i, child := range children {
+		if child == node {
+			g.Current.Children = append(children[:i:i], children[i+1:]...)
+			break
+		}
+	}
+}
+
+// Takeback goes back to the position before the current move. The
+// move stays in the game, so that if a different move is played next,
+// it becomes a variation.
+func (g *Game) Takeback() {
+	if g.Current.Parent != nil {
+		g.Current = g.Current.Parent
+	}
+}
+
+// HasMoves is true if at least one move has been played.
+func (g *Game) HasMoves() bool {
+	return len(g.Root.Children) > 0
+}
+
+// WritePgn writes the game in PGN.
+func (g *Game) WritePgn(w io.Writer) error {
+	tags := [][2]string{
+		{"Event", g.Tags.Event},
+		{"Site", g.Tags.Site},
+		{"Date", g.Tags.Date},
+		{"Round", g.Tags.Round},
+		{"White", g.Tags.White},
+		{"Black", g.Tags.Black},
+		{"Result", g.Result},
+	}
+	if g.Tags.Board != "" {
+		tags = append(tags, [2]string{"Board", g.Tags.Board})
+	}
+	if g.Chess960 {
+		tags = append(tags, [2]string{"Variant", "Chess960"})
+	}
+	if g.StartFen != "" && g.StartFen != StartingFen {
+		tags = append(tags, [2]string{"SetUp", "1"},
+			[2]string{"FEN", g.StartFen})
+	}
+
+	var lines []string
+	for _, tag := range tags {
+		value := tag[1]
+		if value == "" {
+			value = "?"
+		}
+		value = strings.Replace(value, `\`, `\\`, -1)
+		value = strings.Replace(value, `"`, `\"`, -1)
+		lines = append(lines, fmt.Sprintf("[%s \"%s\"]", tag[0], value))
+	}
+	lines = append(lines, "")
+
+	var tokens []string
+	writeMovetext(&tokens, g.Root, true)
+	tokens = append(tokens, g.Result)
+	lines = append(lines, wrapTokens(tokens, 79)...)
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n\n")
+	return err
+}
+
+// writeMovetext appends the moves following node to tokens: the main
+// line, with any variations in brackets after the move they replace.
+// needNumber says whether the first move needs a move number even if
+// it's Black's (as it does at the start of a game or variation, or
+// after a comment or variation has interrupted the flow).
+func writeMovetext(tokens *[]string, node *GameNode, needNumber bool) {
+	for len(node.Children) > 0 {
+		main := node.Children[0]
+		writeMove(tokens, main, needNumber)
+		needNumber = main.HasClock
+
+		for _, variation := range node.Children[1:] {
+			*tokens = append(*tokens, "(")
+			writeMove(tokens, variation, true)
+			writeMovetext(tokens, variation, variation.HasClock)
+			*tokens = append(*tokens, ")")
+			needNumber = true
+		}
+
+		node = main
+	}
+}
+
+func writeMove(tokens *[]string, node *GameNode, needNumber bool) {
+	if node.Side == chess.White {
+		*tokens = append(*tokens, fmt.Sprintf("%d.", node.MoveNumber))
+	} else if needNumber {
+		*tokens = append(*tokens, fmt.Sprintf("%d...", node.MoveNumber))
+	}
+	*tokens = append(*tokens, node.San)
+	if node.HasClock {
+		*tokens = append(*tokens,
+			fmt.Sprintf("{[%%clk %s]}", FormatClockTime(node.Clock)))
+	}
+}
+
+// wrapTokens joins tokens with spaces into lines of at most width
+// characters (unless a single token is longer).
+func wrapTokens(tokens []string, width int) []string {
+	var lines []string
+	line := ""
+	for _, token := range tokens {
+		if line != "" && len(line)+1+len(token) > width {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line != "" {
+			line += " "
+		}
+		line += token
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
@@ -0,0 +1,179 @@
+package godgt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// GameRecorder builds PGN games from the events coming out of a
+// MessageProcessor, and keeps them in a file. The file is rewritten
+// after every move, by writing a new copy and renaming it over the
+// old one, so that a crash at any point leaves either the old or the
+// new version of the file, never half of one.
+//
+// Games already in the file when the recorder is created are kept;
+// games recorded in this session are added after them.
+type GameRecorder struct {
+	Filename string
+
+	// The tags to give each new game. If Date is empty, the date the
+	// game started is used.
+	Tags GameTags
+
+	// Whatever was in the file before we started.
+	previous []byte
+
+	// Games finished in this session.
+	finished []*Game
+
+	// The game in progress, if any.
+	Game *Game
+}
+
+func NewGameRecorder(filename string, tags GameTags) (*GameRecorder, error) {
+	previous, err := ioutil.ReadFile(filename)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return &GameRecorder{
+		Filename: filename,
+		Tags:     tags,
+		previous: previous,
+	}, nil
+}
+
+// ProcessEvent updates the game in progress from an event, and
+// rewrites the file if anything changed.
+func (gr *GameRecorder) ProcessEvent(event *Event) error {
+	if event.ResetEvent != nil {
+		// A resumed game carries on as a new one from the position
+		// it resumed from, since the moves that led there are
+		// unknown.
+		gr.NewGame(event.ResetEvent.Fen)
+		gr.Game.Chess960 = event.ResetEvent.Chess960
+		return nil
+	}
+	if event.ResultEvent != nil {
+		return gr.FinishGame(event.ResultEvent.Result)
+	}
+	if event.MoveEvent != nil {
+		if gr.Game == nil {
+			gr.NewGame(event.MoveEvent.FenBefore)
+		}
+		gr.Game.AddMove(event.MoveEvent)
+		gr.Game.Result = resultOfPosition(event.MoveEvent.FenAfter)
+		return gr.Write()
+	}
+	if event.TakebackEvent != nil && gr.Game != nil {
+		gr.Game.Takeback()
+		gr.Game.Result = ResultUnknown
+		return gr.Write()
+	}
+	return nil
+}
+
+// NewGame starts a new game from a position. The game in progress (if
+// there is one, and it has any moves) is finished, with whatever
+// result it has.
+func (gr *GameRecorder) NewGame(fen string) {
+	if gr.Game != nil && gr.Game.HasMoves() {
+		gr.finished = append(gr.finished, gr.Game)
+	}
+	tags := gr.Tags
+	if tags.Date == "" {
+		tags.Date = time.Now().Format("2006.01.02")
+	}
+	gr.Game = NewGame(tags, fen)
+}
+
+// FinishGame ends the game in progress with a result (one of the
+// Result* constants), for example because the players agreed a draw.
+// The next move starts a new game from the position before it.
+func (gr *GameRecorder) FinishGame(result string) error {
+	if gr.Game == nil {
+		return nil
+	}
+	gr.Game.Result = result
+	err := gr.Write()
+	if gr.Game.HasMoves() {
+		gr.finished = append(gr.finished, gr.Game)
+	}
+	gr.Game = nil
+	return err
+}
+
+// Write rewrites the file with every game recorded so far.
+func (gr *GameRecorder) Write() error {
+	var buffer bytes.Buffer
+	buffer.Write(gr.previous)
+	games := gr.finished
+	if gr.Game != nil && gr.Game.HasMoves() {
+		games = append(games[:len(games):len(games)], gr.Game)
+	}
+	for _, game := range games {
+		err := game.WritePgn(&buffer)
+		if err != nil {
+			return err
+		}
+	}
+
+	dir, base := filepath.Split(gr.Filename)
+	if dir == "" {
+		dir = "."
+	}
+	tmp, err := ioutil.TempFile(dir, base+".tmp")
+	if err != nil {
+		return err
+	}
+	// TempFile creates the file readable only by us; keep the mode of
+	// the file it replaces, or use the usual one for a new file.
+	mode := os.FileMode(0644)
+	if info, serr := os.Stat(gr.Filename); serr == nil {
+		mode = info.Mode().Perm()
+	}
+	err = tmp.Chmod(mode)
+	if err == nil {
+		_, err = tmp.Write(buffer.Bytes())
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	cerr := tmp.Close()
+	if err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	err = os.Rename(tmp.Name(), gr.Filename)
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// resultOfPosition returns the result if the game is over by
+// checkmate or stalemate, and ResultUnknown otherwise.
+func resultOfPosition(fen string) string {
+	board, err := chess.ParseFen(fen)
+	if err != nil {
+		return ResultUnknown
+	}
+	check, mate := board.IsCheckOrMate()
+	if !mate {
+		return ResultUnknown
+	}
+	if !check {
+		return ResultDraw
+	}
+	if board.SideToMove == chess.White {
+		return ResultBlackWins
+	}
+	return ResultWhiteWins
+}
@@ -0,0 +1,94 @@
+package godgt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/malbrecht/chess"
+)
+
+// gameTests give the field updates for a game, in the same form as
+// matchMoveTests, and the movetext that should be recorded for it.
+var gameTests = []struct {
+	name     string
+	fen      string
+	updates  string
+	movetext string
+}{
+	{"moves", StartingFen, "e2 Pe4 | e7 pe5 | g1 Nf3 |", "1. e4 e5 2. Nf3 *"},
+	{"O-O, rook first", castlingFen, "h1 Rf1 | e1 Kg1 |", "1. O-O *"},
+	{"O-O-O, rook first, then a reply", castlingFen,
+		"a1 Rd1 | e1 Kc1 | a7 pa6 |", "1. O-O-O a6 *"},
+}
+
+func TestGameRecording(t *testing.T) {
+	for _, test := range gameTests {
+		t.Run(test.name, func(t *testing.T) {
+			board, err := chess.ParseFen(test.fen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mp := NewMessageProcessor()
+			mp.sensors = board.Piece
+			mp.startGame(board, false)
+
+			game := NewGame(GameTags{}, test.fen)
+			processTestUpdates(t, mp, test.updates)
+			for len(mp.Events) > 0 {
+				event := <-mp.Events
+				if event.MoveEvent != nil {
+					game.AddMove(event.MoveEvent)
+				}
+				if event.TakebackEvent != nil {
+					game.Takeback()
+				}
+			}
+
+			var buffer bytes.Buffer
+			err = game.WritePgn(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			pgn := strings.TrimSpace(buffer.String())
+			movetext := pgn[strings.LastIndex(pgn, "\n")+1:]
+			if movetext != test.movetext {
+				t.Fatalf("got %q, want %q", movetext, test.movetext)
+			}
+		})
+	}
+}
+
+// TestGameRecorderMode checks that rewriting the games file leaves it
+// readable by others, or as it was if it already existed.
+func TestGameRecorderMode(t *testing.T) {
+	for _, existing := range []os.FileMode{0, 0600, 0664} {
+		filename := filepath.Join(t.TempDir(), "games.pgn")
+		want := existing
+		if existing == 0 {
+			want = 0644
+		} else if err := ioutil.WriteFile(filename, nil, existing); err != nil {
+			t.Fatal(err)
+		} else if err := os.Chmod(filename, existing); err != nil {
+			t.Fatal(err)
+		}
+
+		gr, err := NewGameRecorder(filename, GameTags{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := gr.Write(); err != nil {
+			t.Fatal(err)
+		}
+		info, err := os.Stat(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != want {
+			t.Errorf("existing mode %v: got %v, want %v", existing, info.Mode().Perm(), want)
+		}
+	}
+}
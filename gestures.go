@@ -0,0 +1,234 @@
+package godgt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/malbrecht/chess"
+)
+
+// GestureKind is the physical part of a gesture.
+type GestureKind int
+
+const (
+	// A piece lifted and put back on the same square, with nothing
+	// else in the air.
+	GestureReplace GestureKind = iota
+	// A piece that isn't part of the game (e.g. a spare queen) put
+	// down on an empty square.
+	GesturePlace
+	// The two kings swapped over.
+	GestureSwapKings
+)
+
+// GestureAction is what a gesture means.
+type GestureAction string
+
+// The actions that MessageProcessor carries out itself. All actions,
+// including these, are reported as a SignalEvent, so anything else
+// (such as the engine actions) is up to the application.
+const (
+	ActionWhiteToMove    GestureAction = "white to move"
+	ActionBlackToMove    GestureAction = "black to move"
+	ActionToggleCastling GestureAction = "toggle castling"
+	ActionNewGame        GestureAction = "new game"
+	ActionTakeback       GestureAction = "takeback"
+)
+
+// Actions for the application.
+const (
+	ActionMoveNow     GestureAction = "engine move now"
+	ActionFlipBoard   GestureAction = "flip board"
+	ActionEngineLevel GestureAction = "engine level"
+	ActionSaveGame    GestureAction = "save game"
+)
+
+var knownActions = []GestureAction{
+	ActionWhiteToMove,
+	ActionBlackToMove,
+	ActionToggleCastling,
+	ActionNewGame,
+	ActionTakeback,
+	ActionMoveNow,
+	ActionFlipBoard,
+	ActionEngineLevel,
+	ActionSaveGame,
+}
+
+// Gesture maps a physical pattern to an action.
+type Gesture struct {
+	Kind GestureKind
+
+	// The piece involved, for GestureReplace and GesturePlace.
+	Piece chess.Piece
+
+	// The square involved, or chess.NoSquare for any square.
+	Square chess.Sq
+
+	Action GestureAction
+
+	// The number following the action, for actions that take one
+	// (e.g. "engine level 3").
+	Argument int
+
+	// The line that the gesture was defined by.
+	Text string
+}
+
+// GestureTable is a list of gestures; the first one to match wins.
+type GestureTable []*Gesture
+
+// defaultGestures are the gestures that MessageProcessor has always
+// understood.
+const defaultGestures = `
+replace K   => white to move
+replace k   => black to move
+replace Ra1 => toggle castling
+replace Rh1 => toggle castling
+replace ra8 => toggle castling
+replace rh8 => toggle castling
+`
+
+// DefaultGestures returns the built-in gesture table.
+func DefaultGestures() GestureTable {
+	table, err := ParseGestures(strings.NewReader(defaultGestures))
+	if err != nil {
+		panic(err)
+	}
+	return table
+}
+
+// LoadGestures reads a gesture table from a file. See ParseGestures
+// for the format.
+func LoadGestures(filename string) (GestureTable, error) {
+	fh, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer fh.Close()
+	return ParseGestures(fh)
+}
+
+// ParseGestures reads a gesture table, one gesture per line, in the
+// form "pattern => action". Blank lines and lines starting with # are
+// ignored. The patterns are:
+//
+//	replace Ke1    lift a piece and put it back on the same square
+//	replace k      ... on any square
+//	place Qe4      put a piece that isn't in play on an empty square
+//	swap kings     swap the two kings over
+//
+// Pieces are given as FEN letters (upper case for White). The actions
+// are the Action* constants, e.g.:
+//
+//	swap kings => new game
+//	replace qd8 => takeback
+//	place Qa4 => engine level 1
+func ParseGestures(r io.Reader) (GestureTable, error) {
+	var table GestureTable
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		gesture, err := parseGesture(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", lineNumber, err)
+		}
+		table = append(table, gesture)
+	}
+	return table, scanner.Err()
+}
+
+func parseGesture(line string) (*Gesture, error) {
+	parts := strings.SplitN(line, "=>", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected 'pattern => action': %s", line)
+	}
+
+	gesture := &Gesture{
+		Square: chess.NoSquare,
+		Text:   line,
+	}
+
+	pattern := strings.Fields(parts[0])
+	switch {
+	case len(pattern) == 2 && pattern[0] == "swap" && pattern[1] == "kings":
+		gesture.Kind = GestureSwapKings
+	case len(pattern) == 2 && (pattern[0] == "replace" || pattern[0] == "place"):
+		gesture.Kind = GestureReplace
+		if pattern[0] == "place" {
+			gesture.Kind = GesturePlace
+		}
+		if len(pattern[1]) == 1 {
+			gesture.Piece = PieceFromFenChar(pattern[1][0])
+		} else {
+			piece, square, err := ParsePieceSquare(pattern[1])
+			if err != nil {
+				return nil, err
+			}
+			gesture.Piece = piece
+			gesture.Square = square
+		}
+		if gesture.Piece == chess.NoPiece {
+			return nil, fmt.Errorf("a piece is needed: %s", parts[0])
+		}
+		if gesture.Kind == GesturePlace && gesture.Square == chess.NoSquare {
+			return nil, fmt.Errorf("a square is needed: %s", parts[0])
+		}
+	default:
+		return nil, fmt.Errorf("unknown pattern: %s", parts[0])
+	}
+
+	action := strings.Join(strings.Fields(parts[1]), " ")
+	// A trailing number is the argument.
+	if i := strings.LastIndex(action, " "); i >= 0 {
+		if n, err := strconv.Atoi(action[i+1:]); err == nil {
+			gesture.Argument = n
+			action = action[:i]
+		}
+	}
+	for _, known := range knownActions {
+		if GestureAction(action) == known {
+			gesture.Action = known
+			return gesture, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown action: %s", action)
+}
+
+// Match returns the first gesture of the given kind which matches a
+// piece and square, or nil if there isn't one. For GestureSwapKings,
+// the piece and square are ignored.
+func (gt GestureTable) Match(kind GestureKind, piece chess.Piece, square chess.Sq) *Gesture {
+	for _, gesture := range gt {
+		if gesture.Kind != kind {
+			continue
+		}
+		if kind == GestureSwapKings {
+			return gesture
+		}
+		if gesture.Piece != piece {
+			continue
+		}
+		if gesture.Square != chess.NoSquare && gesture.Square != square {
+			continue
+		}
+		return gesture
+	}
+	return nil
+}
+
+func (g *Gesture) ActionString() string {
+	if g.Argument != 0 {
+		return fmt.Sprintf("%s %d", g.Action, g.Argument)
+	}
+	return string(g.Action)
+}
@@ -0,0 +1,50 @@
+package godgt
+
+import (
+	"errors"
+	"log"
+	"time"
+)
+
+var ERR_BATTERY_STATUS_TRUNCATED = errors.New("Battery status message too short")
+
+// The documentation of the battery status message (see dgtconstants.go)
+// lists nine bytes, but gives a size that only allows for four, so we
+// take as much as we're given.
+func (dgtboard *DgtBoard) handleBatteryStatus(arguments []byte) (*Message, error) {
+	if len(arguments) < 1 {
+		return nil, ERR_BATTERY_STATUS_TRUNCATED
+	}
+	bytes := make([]int, 9)
+	for i := range bytes {
+		if i < len(arguments) {
+			bytes[i] = int(arguments[i])
+		}
+	}
+
+	batteryStatus := &BatteryStatus{
+		Capacity: bytes[0],
+		OnTime: time.Duration(bytes[3])*time.Hour +
+			time.Duration(bytes[4])*time.Minute,
+		StandbyTime: time.Duration(bytes[5])*24*time.Hour +
+			time.Duration(bytes[6])*time.Hour +
+			time.Duration(bytes[7])*time.Minute,
+		Charging:    bytes[8]&0x01 != 0,
+		Discharging: bytes[8]&0x02 != 0,
+	}
+	// 0x7f means the time left isn't known.
+	if len(arguments) >= 3 && bytes[1] != 0x7f && bytes[2] != 0x7f {
+		batteryStatus.TimeLeft = time.Duration(bytes[1])*time.Hour +
+			time.Duration(bytes[2])*time.Minute
+		batteryStatus.HasTimeLeft = true
+	}
+	// Without the status byte, there's no telling whether the board
+	// is charging.
+	if len(arguments) >= 9 && !batteryStatus.Charging &&
+		batteryStatus.Capacity <= dgtboard.LowBattery {
+		batteryStatus.Low = true
+		log.Println("WARNING: " + batteryStatus.ToString())
+	}
+
+	return NewBatteryStatusMessage(batteryStatus), nil
+}
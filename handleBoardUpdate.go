@@ -1,17 +1,38 @@
 package godgt
 
 import (
+	"errors"
+	"fmt"
 	"log"
 
 	"github.com/malbrecht/chess"
 )
 
+var ERR_BAD_PIECE_CODE = errors.New("Bad piece code")
+
+// handleBoardDump decodes a dump of the whole board. If one of the
+// magic result pieces is on the board, the dump isn't a position from
+// a game at all, so a ResultSignal is returned instead.
 func (dgtboard *DgtBoard) handleBoardDump(arguments []byte) (*Message, error) {
 	log.Println("DGT_BOARD_DUMP")
-	board := &chess.Board{}
+	// The board can't tell us anything about the game, so assume
+	// it's White to move at the start of one.
+	board := &chess.Board{
+		SideToMove: chess.White,
+		EpSquare:   chess.NoSquare,
+		MoveNr:     1,
+	}
 	for squareIndex, gdtPieceCode := range arguments {
-		chessPiece := dgtboard.getChessPieceByGdtPieceCode(gdtPieceCode)
 		square := dgtboard.getChessSquareFromIndex(squareIndex)
+		result := getResultByGdtPieceCode(gdtPieceCode)
+		if result != "" {
+			resultSignal := NewResultSignal(result, square)
+			return NewResultSignalMessage(resultSignal), nil
+		}
+		chessPiece, err := dgtboard.getChessPieceByGdtPieceCode(gdtPieceCode)
+		if err != nil {
+			return nil, err
+		}
 		board.Piece[square] = chessPiece
 	}
 
@@ -32,13 +53,13 @@ func (dgtboard *DgtBoard) handleBoardDump(arguments []byte) (*Message, error) {
 		board.CastleSq[chess.WhiteOO] = chess.NoSquare
 	}
 
-	if board.Piece[chess.E8] == chess.WK {
-		if board.Piece[chess.A8] == chess.WR {
+	if board.Piece[chess.E8] == chess.BK {
+		if board.Piece[chess.A8] == chess.BR {
 			board.CastleSq[chess.BlackOOO] = chess.A8
 		} else {
 			board.CastleSq[chess.BlackOOO] = chess.NoSquare
 		}
-		if board.Piece[chess.H8] == chess.WR {
+		if board.Piece[chess.H8] == chess.BR {
 			board.CastleSq[chess.BlackOO] = chess.H8
 		} else {
 			board.CastleSq[chess.BlackOO] = chess.NoSquare
@@ -66,35 +87,71 @@ func (dgtboard *DgtBoard) getChessSquareFromIndex(dgtSquare int) chess.Sq {
 	return chess.Square(fileIndex, rankIndex)
 }
 
-func (dgtboard *DgtBoard) getChessPieceByGdtPieceCode(gdtPieceCode byte) chess.Piece {
+// getChessPieceByGdtPieceCode returns the chess piece for a piece
+// code. The magic result pieces aren't chess pieces, so they're
+// rejected along with any unknown codes; see getResultByGdtPieceCode.
+func (dgtboard *DgtBoard) getChessPieceByGdtPieceCode(gdtPieceCode byte) (chess.Piece, error) {
 	switch gdtPieceCode {
 	case WPAWN:
-		return chess.WP
+		return chess.WP, nil
 	case WKNIGHT:
-		return chess.WN
+		return chess.WN, nil
 	case WBISHOP:
-		return chess.WB
+		return chess.WB, nil
 	case WROOK:
-		return chess.WR
+		return chess.WR, nil
 	case WQUEEN:
-		return chess.WQ
+		return chess.WQ, nil
 	case WKING:
-		return chess.WK
+		return chess.WK, nil
 	case BPAWN:
-		return chess.BP
+		return chess.BP, nil
 	case BKNIGHT:
-		return chess.BN
+		return chess.BN, nil
 	case BBISHOP:
-		return chess.BB
+		return chess.BB, nil
 	case BROOK:
-		return chess.BR
+		return chess.BR, nil
 	case BQUEEN:
-		return chess.BQ
+		return chess.BQ, nil
 	case BKING:
-		return chess.BK
+		return chess.BK, nil
 	case EMPTY:
-		return 0
+		return chess.NoPiece, nil
+	default:
+		return chess.NoPiece, fmt.Errorf("%s: 0x%02x", ERR_BAD_PIECE_CODE, gdtPieceCode)
+	}
+}
+
+// getGdtPieceCodeByChessPiece is the inverse of
+// getChessPieceByGdtPieceCode.
+func getGdtPieceCodeByChessPiece(piece chess.Piece) byte {
+	switch piece {
+	case chess.WP:
+		return WPAWN
+	case chess.WN:
+		return WKNIGHT
+	case chess.WB:
+		return WBISHOP
+	case chess.WR:
+		return WROOK
+	case chess.WQ:
+		return WQUEEN
+	case chess.WK:
+		return WKING
+	case chess.BP:
+		return BPAWN
+	case chess.BN:
+		return BKNIGHT
+	case chess.BB:
+		return BBISHOP
+	case chess.BR:
+		return BROOK
+	case chess.BQ:
+		return BQUEEN
+	case chess.BK:
+		return BKING
 	default:
-		panic("Bad piece")
+		return EMPTY
 	}
 }
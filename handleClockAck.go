@@ -0,0 +1,44 @@
+package godgt
+
+import "log"
+
+// isClockAck works out whether the arguments of a DGT_BWTIME message
+// are a Clock Ack rather than a clock reading.
+func isClockAck(arguments []byte) bool {
+	return arguments[0]&0x0f == 0x0a || arguments[3]&0x0f == 0x0a
+}
+
+func (dgtboard *DgtBoard) handleClockAck(arguments []byte) (*Message, error) {
+	// As with handleTime, byteN is byte N of the whole message, so
+	// byte3 is arguments[0].
+	byte3 := arguments[0]
+	byte4 := arguments[1]
+	byte5 := arguments[2]
+	byte6 := arguments[3]
+	byte7 := arguments[4]
+	byte8 := arguments[5]
+
+	ack0 := (byte4 & 0x7f) | ((byte6 << 3) & 0x80)
+	ack1 := (byte5 & 0x7f) | ((byte6 << 2) & 0x80)
+	ack2 := (byte7 & 0x7f) | ((byte3 << 3) & 0x80)
+	ack3 := (byte8 & 0x7f) | ((byte3 << 2) & 0x80)
+
+	clockAck := NewClockAck(ack0, ack1, ack2, ack3)
+	log.Println(clockAck.ToString())
+
+	if clockAck.IsAutoGenerated() {
+		if ack1 == CLOCK_ACK_BUTTON {
+			button := NewClockButtonPress(ClockButton(ack3))
+			return NewClockButtonPressMessage(button), nil
+		}
+	} else {
+		// Someone might be waiting for this in SendClockCommand.
+		// If nobody is, don't hang around.
+		select {
+		case dgtboard.clockAcks <- clockAck:
+		default:
+		}
+	}
+
+	return NewClockAckMessage(clockAck), nil
+}
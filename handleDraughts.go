@@ -0,0 +1,76 @@
+package godgt
+
+import (
+	"fmt"
+	"log"
+)
+
+// handleDraughtsBoardDump decodes the 100 field dump that a draughts
+// board sends in answer to DGT_SEND_BRD.
+func (dgtboard *DgtBoard) handleDraughtsBoardDump(arguments []byte) (*Message, error) {
+	log.Println("DGT_BOARD_DUMP (draughts)")
+	position := &DraughtsPosition{
+		LightSquares: dgtboard.DraughtsOnLightSquares,
+	}
+	for field, gdtPieceCode := range arguments {
+		piece, err := getDraughtsPieceByGdtPieceCode(gdtPieceCode)
+		if err != nil {
+			return nil, err
+		}
+		position.Fields[field] = piece
+	}
+	return NewDraughtsBoardUpdateMessage(NewDraughtsBoardUpdate(position)), nil
+}
+
+// handleDraughts50Dump decodes the dumps of just the 50 dark squares
+// (DGT_SEND_BRD_50B) or just the 50 light ones (DGT_SEND_BRD_50W),
+// which come in the same order as the squares are numbered.
+func (dgtboard *DgtBoard) handleDraughts50Dump(arguments []byte, lightSquares bool) (*Message, error) {
+	log.Println("DGT_BOARD_DUMP_50")
+	position := &DraughtsPosition{
+		LightSquares: lightSquares,
+	}
+	for index, gdtPieceCode := range arguments {
+		piece, err := getDraughtsPieceByGdtPieceCode(gdtPieceCode)
+		if err != nil {
+			return nil, err
+		}
+		position.Fields[DraughtsField(index+1, lightSquares)] = piece
+	}
+	return NewDraughtsBoardUpdateMessage(NewDraughtsBoardUpdate(position)), nil
+}
+
+func (dgtboard *DgtBoard) handleDraughtsFieldUpdate(arguments []byte) (*Message, error) {
+	log.Println("Field update (draughts)")
+	field := int(arguments[0])
+	if field > 99 {
+		return nil, fmt.Errorf("Draughts field update: bad field %d", field)
+	}
+	piece, err := getDraughtsPieceByGdtPieceCode(arguments[1])
+	if err != nil {
+		return nil, err
+	}
+	square := DraughtsSquare(field, dgtboard.DraughtsOnLightSquares)
+	fieldUpdate := NewDraughtsFieldUpdate(field, square, piece)
+	return NewDraughtsFieldUpdateMessage(fieldUpdate), nil
+}
+
+// getDraughtsPieceByGdtPieceCode returns the draughts piece for a
+// piece code. These overlap with the chess piece codes, which is why a
+// DgtBoard has to be told that it's talking to a draughts board.
+func getDraughtsPieceByGdtPieceCode(gdtPieceCode byte) (DraughtsPiece, error) {
+	switch gdtPieceCode {
+	case EMPTY:
+		return DraughtsEmpty, nil
+	case WDISK:
+		return DraughtsWhiteMan, nil
+	case BDISK:
+		return DraughtsBlackMan, nil
+	case WCROWN:
+		return DraughtsWhiteKing, nil
+	case BCROWN:
+		return DraughtsBlackKing, nil
+	default:
+		return DraughtsEmpty, fmt.Errorf("%s: 0x%02x", ERR_BAD_PIECE_CODE, gdtPieceCode)
+	}
+}
@@ -0,0 +1,91 @@
+package godgt
+
+import (
+	"errors"
+	"log"
+)
+
+var ERR_EE_MOVES_TRUNCATED = errors.New("EE moves: record cut short")
+
+// handleEEMoves decodes the contents of the board's move storage; see
+// the description of the storage format in dgtconstants.go. Decoding
+// stops at EE_EOF. If the storage is damaged (a record is cut short),
+// everything decoded up to that point is returned along with an error
+// message in the log, since a partial game is better than none.
+func (dgtboard *DgtBoard) handleEEMoves(arguments []byte) (*Message, error) {
+	events, err := dgtboard.decodeEEMoves(arguments)
+	if err != nil {
+		log.Println(err)
+	}
+	eeMoves := NewEEMoves(events, dgtboard.WhiteOnRight)
+	return NewEEMovesMessage(eeMoves), nil
+}
+
+func (dgtboard *DgtBoard) decodeEEMoves(data []byte) ([]*EEEvent, error) {
+	var events []*EEEvent
+	for i := 0; i < len(data); {
+		tag := data[i]
+		switch {
+		case tag == EE_NOP || tag == EE_NOP2:
+			i++
+		case tag == EE_EOF:
+			events = append(events, &EEEvent{Type: EEEndOfFile, Tag: tag})
+			return events, nil
+		case tag&0xf0 == EE_FIELDCHANGE:
+			if i+2 > len(data) {
+				return events, ERR_EE_MOVES_TRUNCATED
+			}
+			pieceCode := tag & 0x0f
+			fieldNumber := data[i+1]
+			i += 2
+			piece, err := dgtboard.getChessPieceByGdtPieceCode(pieceCode)
+			if err != nil || fieldNumber > 63 {
+				log.Printf("EE moves: ignoring field change %02x %02x\n",
+					tag, fieldNumber)
+				continue
+			}
+			square := dgtboard.getChessSquareFromGdtFieldNumber(fieldNumber)
+			events = append(events, &EEEvent{
+				Type:        EEFieldChange,
+				Tag:         tag,
+				FieldUpdate: NewFieldUpdate(square, piece),
+			})
+		case tag == EE_POWERUP:
+			if i+65 > len(data) {
+				return events, ERR_EE_MOVES_TRUNCATED
+			}
+			dump := data[i+1 : i+65]
+			i += 65
+			message, err := dgtboard.handleBoardDump(dump)
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			events = append(events, &EEEvent{
+				Type:  EEPowerUp,
+				Tag:   tag,
+				Board: message.BoardUpdate.Board,
+			})
+		case (tag&0xf0 == EE_LEFT_TIME || tag&0xf0 == EE_RIGHT_TIME) && tag&0x0f <= 9:
+			if i+3 > len(data) {
+				return events, ERR_EE_MOVES_TRUNCATED
+			}
+			eventType := EELeftTime
+			if tag&0xf0 == EE_RIGHT_TIME {
+				eventType = EERightTime
+			}
+			events = append(events, &EEEvent{
+				Type: eventType,
+				Tag:  tag,
+				Time: clockTime(tag&0x0f, data[i+1], data[i+2]),
+			})
+			i += 3
+		default:
+			events = append(events, &EEEvent{Type: EEMarker, Tag: tag})
+			i++
+		}
+	}
+	// The storage should always end with EE_EOF, but there's no
+	// point in complaining if it doesn't.
+	return events, nil
+}
@@ -12,7 +12,17 @@ func (dgtboard *DgtBoard) handleFieldUpdate(arguments []byte) (*Message, error)
 	gdtPieceCode := arguments[1]
 
 	square := dgtboard.getChessSquareFromGdtFieldNumber(fieldNumber)
-	piece := dgtboard.getChessPieceByGdtPieceCode(gdtPieceCode)
+
+	result := getResultByGdtPieceCode(gdtPieceCode)
+	if result != "" {
+		resultSignal := NewResultSignal(result, square)
+		return NewResultSignalMessage(resultSignal), nil
+	}
+
+	piece, err := dgtboard.getChessPieceByGdtPieceCode(gdtPieceCode)
+	if err != nil {
+		return nil, err
+	}
 
 	fieldUpdate := NewFieldUpdate(square, piece)
 	fieldUpdateMessage := NewFieldUpdateMessage(fieldUpdate)
@@ -40,3 +50,12 @@ func (dgtboard *DgtBoard) getChessSquareFromGdtFieldNumber(gdtFieldNumber byte)
 
 	return chess.Square(fileIndex, rankIndex)
 }
+
+// getGdtFieldNumberFromChessSquare is the inverse of
+// getChessSquareFromGdtFieldNumber: it turns a chess.Sq (a1=0, h8=63)
+// back into a DGT field number (a8=0, h1=63).
+func getGdtFieldNumberFromChessSquare(square chess.Sq) byte {
+	fileIndex := square.File()
+	rankIndex := 7 - square.Rank()
+	return byte(rankIndex*8 + fileIndex)
+}
@@ -0,0 +1,38 @@
+package godgt
+
+import "strings"
+
+func (dgtboard *DgtBoard) handleSerialNumber(arguments []byte) (*Message, error) {
+	infoUpdate := NewInfoUpdate(InfoSerialNumber, serialNumberText(arguments))
+	return dgtboard.handleInfoUpdate(infoUpdate), nil
+}
+
+func (dgtboard *DgtBoard) handleLongSerialNumber(arguments []byte) (*Message, error) {
+	infoUpdate := NewInfoUpdate(InfoLongSerialNumber, serialNumberText(arguments))
+	return dgtboard.handleInfoUpdate(infoUpdate), nil
+}
+
+func (dgtboard *DgtBoard) handleBusAddress(arguments []byte) (*Message, error) {
+	// Two bytes of 7 bits each, most significant first.
+	address := int(arguments[0])<<7 | int(arguments[1])
+	infoUpdate := NewInfoUpdate(InfoBusAddress, "")
+	infoUpdate.Number = address
+	return dgtboard.handleInfoUpdate(infoUpdate), nil
+}
+
+// serialNumberText turns the ASCII digits of a serial number into a
+// string, without any padding that some boards add.
+func serialNumberText(arguments []byte) string {
+	return strings.TrimRight(string(arguments), " \x00")
+}
+
+// handleInfoUpdate passes the update to anyone waiting in Identify,
+// as well as returning it as a message.
+func (dgtboard *DgtBoard) handleInfoUpdate(infoUpdate *InfoUpdate) *Message {
+	select {
+	case dgtboard.infoUpdates <- infoUpdate:
+	default:
+		// Nobody is waiting for it.
+	}
+	return NewInfoUpdateMessage(infoUpdate)
+}
@@ -5,10 +5,12 @@ import (
 	"log"
 )
 
-var ERR_CLOCK_ACK = errors.New("Clock ACK (Unhandled)")
-var ERR_CLOCK_NOT_RUNNNING = errors.New("Clock Not Running")
 var ERR_CLOCK_NOT_CONNECTED = errors.New("Clock Not Connected")
 
+// Deprecated: handleTime no longer returns ERR_CLOCK_NOT_RUNNNING; a
+// stopped clock is reported in the TimeUpdate.
+var ERR_CLOCK_NOT_RUNNNING = errors.New("Clock Not Running")
+
 func (dgtboard *DgtBoard) handleTime(arguments []byte) (*Message, error) {
 	log.Println("DGT_BWTIME")
 
@@ -28,42 +30,29 @@ func (dgtboard *DgtBoard) handleTime(arguments []byte) (*Message, error) {
 	// Despite the apparent inconsistency, this almost certainly
 	// refers to "4th byte of the message, which is byte 3
 	// (counting from 0)".
-	byte3 := arguments[0]
-
-	if byte3&0x0f == 0x0a {
-		return nil, ERR_CLOCK_ACK
+	if isClockAck(arguments) {
+		return dgtboard.handleClockAck(arguments)
 	}
 
-	rightPlayerHours := int(byte3 & 0x04)                   // 0b 0000 1111
-	rightPlayerFlagFallenAndBlocked := byte3&0x10 == 0x10   // 0b 0001 0000
-	rightPlayerTimePerMoveIndicator := byte3&0x20 == 0x20   // 0b 0010 0000
-	rightPlayerFlagFallenAndIndicated := byte3&0x40 == 0x40 // 0b 0100 0000
-
-	byte4 := arguments[1]
-	rightPlayerMinutes := int(byte4)
-
-	byte5 := arguments[2]
-	rightPlayerSeconds := int(byte5)
-
-	byte6 := arguments[3]
-	leftPlayerHours := int(byte6 & 0x04)                   // 0b 0000 1111
-	leftPlayerFlagFallenAndBlocked := byte6&0x10 == 0x10   // 0b 0001 0000
-	leftPlayerTimePerMoveIndicator := byte6&0x20 == 0x20   // 0b 0010 0000
-	leftPlayerFlagFallenAndIndicated := byte6&0x40 == 0x40 // 0b 0100 0000
-
-	byte7 := arguments[4]
-	leftPlayerMinutes := int(byte7)
+	// Bluetooth boards sometimes answer a clock command with a time
+	// message full of zeroes, which is to be ignored.
+	allZero := true
+	for _, b := range arguments {
+		if b != 0 {
+			allZero = false
+		}
+	}
+	if allZero {
+		return nil, nil
+	}
 
-	byte8 := arguments[5]
-	leftPlayerSeconds := int(byte8)
+	right := decodePlayerClock(arguments[0], arguments[1], arguments[2])
+	left := decodePlayerClock(arguments[3], arguments[4], arguments[5])
 
 	byte9 := arguments[6]
 
-	log.Printf("Byte A: 0b%08b\n", byte9)
-
-	clockRunning := byte9&0x01 == 0x01             // 0b 0000 0001
-	clockRockerLeftDepressed := byte9&0x02 == 0x02 // 0b 0000 0010
-	clockRockerRightDepressed := !clockRockerLeftDepressed
+	clockRunning := byte9&0x01 == 0x01      // 0b 0000 0001
+	leverRightHigh := byte9&0x02 == 0x02    // 0b 0000 0010
 	batteryLow := byte9&0x04 == 0x04        // 0b 0000 0100
 	rightPlayersTurn := byte9&0x08 == 0x08  // 0b 0000 1000
 	leftPlayersTurn := byte9&0x10 == 0x10   // 0b 0001 0000
@@ -73,23 +62,29 @@ func (dgtboard *DgtBoard) handleTime(arguments []byte) (*Message, error) {
 		return nil, ERR_CLOCK_NOT_CONNECTED
 	}
 
-	if !clockRunning {
-		return nil, ERR_CLOCK_NOT_RUNNNING
+	timeUpdate := &TimeUpdate{
+		Left:           left,
+		Right:          right,
+		Running:        clockRunning,
+		LeverRightHigh: leverRightHigh,
+		BatteryLow:     batteryLow,
+		LeftToMove:     leftPlayersTurn,
+		RightToMove:    rightPlayersTurn,
+		WhiteOnRight:   dgtboard.WhiteOnRight,
 	}
-
-	log.Printf("%02d:%02d:%02d - %02d:%02d:%02d\n", leftPlayerHours, leftPlayerMinutes, leftPlayerSeconds, rightPlayerHours, rightPlayerMinutes, rightPlayerSeconds)
-
-	log.Printf("%t %t %t %t %t %t %t %t %t %t %t\n", leftPlayerFlagFallenAndBlocked,
-		leftPlayerTimePerMoveIndicator,
-		leftPlayerFlagFallenAndIndicated,
-		rightPlayerFlagFallenAndBlocked,
-		rightPlayerTimePerMoveIndicator,
-		rightPlayerFlagFallenAndIndicated,
-		clockRockerLeftDepressed, clockRockerRightDepressed,
-		batteryLow, rightPlayersTurn, leftPlayersTurn)
-
-	// FIXME
-	timeUpdate := NewTimeUpdate()
 	timeUpdateMessage := NewTimeUpdateMessage(timeUpdate)
 	return timeUpdateMessage, nil
 }
+
+// decodePlayerClock decodes the three bytes describing one player's
+// side of the clock.
+func decodePlayerClock(hoursAndFlags byte, minutes byte, seconds byte) PlayerClock {
+	return PlayerClock{
+		// Hours are a single BCD digit in the low nibble;
+		// minutes and seconds are two BCD digits each.
+		Remaining:            clockTime(hoursAndFlags&0x0f, minutes, seconds),
+		FlagFallenAndBlocked: hoursAndFlags&0x10 == 0x10, // 0b 0001 0000
+		TimePerMove:          hoursAndFlags&0x20 == 0x20, // 0b 0010 0000
+		FlagFallen:           hoursAndFlags&0x40 == 0x40, // 0b 0100 0000
+	}
+}
@@ -1,6 +1,6 @@
 package godgt
 
 func (dgtboard *DgtBoard) handleTrademarkMessage(arguments []byte) (*Message, error) {
-	info := string(arguments)
-	return NewInfoUpdateMessage(info), nil
+	infoUpdate := NewInfoUpdate(InfoTrademark, string(arguments))
+	return dgtboard.handleInfoUpdate(infoUpdate), nil
 }
@@ -5,6 +5,9 @@ import "fmt"
 func (dgtboard *DgtBoard) handleVersionMessage(arguments []byte) (*Message, error) {
 	major := arguments[0]
 	minor := arguments[1]
-	info := fmt.Sprintf("DGT_VERSION: %d.%02d\n", int(major), int(minor))
-	return NewInfoUpdateMessage(info), nil
+	infoUpdate := NewInfoUpdate(InfoVersion,
+		fmt.Sprintf("%d.%02d", int(major), int(minor)))
+	infoUpdate.Number = int(major)
+	infoUpdate.Minor = int(minor)
+	return dgtboard.handleInfoUpdate(infoUpdate), nil
 }
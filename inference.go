@@ -0,0 +1,196 @@
+package godgt
+
+import (
+	"github.com/malbrecht/chess"
+)
+
+// InferenceState says how sure an InferenceEngine is of the position.
+type InferenceState int
+
+const (
+	// The sensors don't show a position we recognise yet, but they
+	// might be in the middle of a move, so we're waiting to see.
+	InferenceSettling InferenceState = iota
+	// The sensors show the position we expect.
+	InferenceConfirmed
+	// The sensors have settled on a position that can't be reached
+	// from the last confirmed position; someone needs to put the
+	// pieces back.
+	InferenceDiverged
+)
+
+func (is InferenceState) String() string {
+	switch is {
+	case InferenceSettling:
+		return "settling"
+	case InferenceConfirmed:
+		return "confirmed"
+	case InferenceDiverged:
+		return "diverged, please restore the position"
+	default:
+		return "unknown"
+	}
+}
+
+// InferenceEngine works out which moves have been played by comparing
+// snapshots of the sensors with the positions that can be reached
+// from the last confirmed position. It doesn't care what order the
+// field updates arrived in, or how many there were: a piece slid across several
+// squares, or a storm of updates from a fumbled piece, only matter
+// once the sensors have settled. And because it looks more than one
+// move ahead, it can catch up when updates were lost altogether (for
+// example, while the board was disconnected).
+type InferenceEngine struct {
+	// The last confirmed position.
+	Position *chess.Board
+
+	State InferenceState
+
+	// The number of moves to look ahead when the sensors are stable.
+	// Unstable snapshots are only ever matched against a single
+	// move, since they're usually a move in progress.
+	MaxDepth int
+}
+
+func NewInferenceEngine(board *chess.Board) *InferenceEngine {
+	return &InferenceEngine{
+		Position: board,
+		State:    InferenceConfirmed,
+		MaxDepth: 3,
+	}
+}
+
+// Confirm tells the engine that the position has been established by
+// other means (for example, a move recognised by MessageProcessor).
+func (ie *InferenceEngine) Confirm(board *chess.Board) {
+	ie.Position = board
+	ie.State = InferenceConfirmed
+}
+
+// Update compares a snapshot of the sensors with the positions that
+// can be reached from the last confirmed position. Stable snapshots
+// come from a board dump, or from a board that has been left alone
+// for a while; anything else is assumed to be a board in the middle
+// of being changed.
+//
+// If exactly one sequence of legal moves (of the shortest possible
+// length) leads to the snapshot, its moves are returned, and the
+// position it leads to becomes the confirmed position. If there's more
+// than one, but they all end up in the same position, that position is
+// still confirmed, but no moves are returned, since the order in which
+// they were played can't be known. Otherwise no moves are returned,
+// and State says why.
+func (ie *InferenceEngine) Update(sensors [64]chess.Piece, stable bool) []chess.Move {
+	if ie.Position.Piece == sensors {
+		ie.State = InferenceConfirmed
+		return nil
+	}
+
+	maxDepth := 1
+	if stable {
+		maxDepth = ie.MaxDepth
+	}
+
+	for depth := 1; depth <= maxDepth; depth++ {
+		var found []inferredLine
+		ie.search(ie.Position, sensors, depth, nil, &found)
+		if len(found) == 0 {
+			continue
+		}
+		if !samePosition(found[0].Board, found[len(found)-1].Board) {
+			// More than one position looks like this; wait for
+			// something to tell them apart.
+			ie.State = InferenceSettling
+			return nil
+		}
+		ie.Position = found[0].Board
+		ie.State = InferenceConfirmed
+		if len(found) > 1 {
+			return nil
+		}
+		return found[0].Moves
+	}
+
+	if stable {
+		ie.State = InferenceDiverged
+	} else if ie.State != InferenceDiverged {
+		// Once diverged, we stay diverged until the pieces are
+		// put right.
+		ie.State = InferenceSettling
+	}
+	return nil
+}
+
+// inferredLine is a sequence of moves found by search, and the position
+// it leads to.
+type inferredLine struct {
+	Moves []chess.Move
+	Board *chess.Board
+}
+
+// maxSquaresPerMove is the most squares that a single move can change
+// (castling changes four).
+const maxSquaresPerMove = 4
+
+// search finds the sequences of exactly depth legal moves from board
+// that end up with the pieces on the squares given by sensors. It
+// stops as soon as it has found two that end up in different
+// positions, since that's enough to know that the answer is ambiguous.
+//
+// Branches which leave more squares differing from the sensors than
+// the remaining moves could possibly change aren't followed.
+func (ie *InferenceEngine) search(board *chess.Board, sensors [64]chess.Piece,
+	depth int, path []chess.Move, found *[]inferredLine) {
+	for _, move := range board.LegalMoves() {
+		if len(*found) > 1 &&
+			!samePosition((*found)[0].Board, (*found)[len(*found)-1].Board) {
+			return
+		}
+		after := board.MakeMove(move)
+		if countDifferences(after.Piece, sensors) > maxSquaresPerMove*(depth-1) {
+			continue
+		}
+		line := append(append([]chess.Move{}, path...), move)
+		if depth == 1 {
+			*found = append(*found, inferredLine{Moves: line, Board: after})
+			continue
+		}
+		ie.search(after, sensors, depth-1, line, found)
+	}
+}
+
+func countDifferences(a [64]chess.Piece, b [64]chess.Piece) int {
+	differences := 0
+	for square := range a {
+		if a[square] != b[square] {
+			differences++
+		}
+	}
+	return differences
+}
+
+// samePosition is true if two boards are the same position as far as
+// the rest of the game is concerned: the same pieces, the same side to
+// move, and the same castling rights. An en passant square only makes
+// a difference if there's a pawn that can actually take en passant.
+func samePosition(a *chess.Board, b *chess.Board) bool {
+	if a.Piece != b.Piece || a.SideToMove != b.SideToMove ||
+		a.CastleSq != b.CastleSq {
+		return false
+	}
+	return a.EpSquare == b.EpSquare ||
+		(!canTakeEnPassant(a) && !canTakeEnPassant(b))
+}
+
+func canTakeEnPassant(board *chess.Board) bool {
+	if board.EpSquare == chess.NoSquare {
+		return false
+	}
+	for _, move := range board.LegalMoves() {
+		piece := board.Piece[move.From]
+		if move.To == board.EpSquare && (piece == chess.WP || piece == chess.BP) {
+			return true
+		}
+	}
+	return false
+}
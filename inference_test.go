@@ -0,0 +1,64 @@
+package godgt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/malbrecht/chess"
+)
+
+// The updates are given as for matchMoveTests, and all start from the
+// standard starting position. The events expected are the moves
+// reported, or "resumed" for a game carried on from a new position.
+var inferenceTests = []struct {
+	name    string
+	updates string
+	events  string
+	fen     string
+}{
+	{"two moves", "e2 Pe4 e7 pe5 |", "e4 e5",
+		"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2"},
+	{"three moves", "e2 Pe4 e7 pe5 e1 Ke2 |", "e4 e5 Ke2",
+		"rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPPKPPP/RNBQ1BNR b kq - 1 2"},
+	{"moves in an unknown order", "g1 Nf3 g8 nf6 b1 Nc3 |", "resumed",
+		"rnbqkb1r/pppppppp/5n2/8/8/2N2N2/PPPPPPPP/R1BQKB1R b KQkq - 3 2"},
+	{"moves in an unknown order, then a move", "g1 Nf3 g8 nf6 b1 Nc3 | e7 pe5 |",
+		"resumed e5",
+		"rnbqkb1r/pppp1ppp/5n2/4p3/8/2N2N2/PPPPPPPP/R1BQKB1R w KQkq e6 0 3"},
+}
+
+func TestInference(t *testing.T) {
+	for _, test := range inferenceTests {
+		t.Run(test.name, func(t *testing.T) {
+			board, err := chess.ParseFen(StartingFen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mp := NewMessageProcessor()
+			mp.sensors = board.Piece
+			mp.startGame(board, false)
+			<-mp.Events
+
+			processTestUpdates(t, mp, test.updates)
+
+			var events []string
+			for len(mp.Events) > 0 {
+				event := <-mp.Events
+				switch {
+				case event.MoveEvent != nil:
+					events = append(events, event.MoveEvent.San)
+				case event.ResetEvent != nil && event.ResetEvent.Resumed:
+					events = append(events, "resumed")
+				case event.ResetEvent != nil:
+					events = append(events, "reset")
+				}
+			}
+			if got := strings.Join(events, " "); got != test.events {
+				t.Fatalf("got %q, want %q", got, test.events)
+			}
+			if mp.Board.Fen() != test.fen {
+				t.Fatalf("got position %s, want %s", mp.Board.Fen(), test.fen)
+			}
+		})
+	}
+}
@@ -1,15 +1,60 @@
 package godgt
 
+import "fmt"
+
+// InfoKind says which of the board's identifying details an InfoUpdate
+// carries.
+type InfoKind int
+
+const (
+	InfoSerialNumber InfoKind = iota
+	InfoLongSerialNumber
+	InfoBusAddress
+	InfoVersion
+	InfoTrademark
+)
+
+func (ik InfoKind) String() string {
+	switch ik {
+	case InfoSerialNumber:
+		return "Serial number"
+	case InfoLongSerialNumber:
+		return "Long serial number"
+	case InfoBusAddress:
+		return "Bus address"
+	case InfoVersion:
+		return "Version"
+	case InfoTrademark:
+		return "Trademark"
+	default:
+		return "Unknown"
+	}
+}
+
+// InfoUpdate is one of the board's answers about itself: its serial
+// number, version and so on. See BoardInfo for collecting them.
 type InfoUpdate struct {
-	info string
+	Kind InfoKind
+
+	// The serial numbers and the trademark, as sent by the board,
+	// and the version as text (e.g. "1.02").
+	Text string
+
+	// The bus address, or the major and minor version numbers.
+	Number int
+	Minor  int
 }
 
-func NewInfoUpdate(info string) *InfoUpdate {
+func NewInfoUpdate(kind InfoKind, text string) *InfoUpdate {
 	return &InfoUpdate{
-		info: info,
+		Kind: kind,
+		Text: text,
 	}
 }
 
 func (iu *InfoUpdate) ToString() string {
-	return iu.info
+	if iu.Kind == InfoBusAddress {
+		return fmt.Sprintf("%s: 0x%04x", iu.Kind, iu.Number)
+	}
+	return fmt.Sprintf("%s: %s", iu.Kind, iu.Text)
 }
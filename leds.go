@@ -0,0 +1,82 @@
+package godgt
+
+import (
+	"github.com/malbrecht/chess"
+)
+
+// LED patterns for DGT_SET_LEDS. The protocol leaves room for more,
+// but so far only these two are defined.
+const (
+	LED_OFF = 0x00
+	LED_ON  = 0x01
+)
+
+// encodeSetLeds builds a DGT_SET_LEDS command, which sets the LEDs of
+// every field from start to end (in DGT field numbering, a8=0) to a
+// pattern.
+func encodeSetLeds(pattern byte, start byte, end byte) []byte {
+	if start > end {
+		start, end = end, start
+	}
+	return []byte{DGT_SET_LEDS, 0x04, pattern, start, end, 0x00}
+}
+
+// SetLeds sets the LEDs of the squares from one square to another to a
+// pattern. The squares in between are those between the two in the
+// board's own numbering, which runs along each rank from a8 to h1; so a
+// range covering more than one rank wraps from the h file of one rank
+// to the a file of the one below. Only the Revelation II has LEDs;
+// other boards ignore the command.
+func (dgtboard *DgtBoard) SetLeds(pattern byte, from chess.Sq, to chess.Sq) error {
+	_, err := dgtboard.WriteBytes(encodeSetLeds(pattern,
+		getGdtFieldNumberFromChessSquare(from),
+		getGdtFieldNumberFromChessSquare(to)))
+	return err
+}
+
+// LightSquare turns on the LED of a single square.
+func (dgtboard *DgtBoard) LightSquare(square chess.Sq) error {
+	return dgtboard.SetLeds(LED_ON, square, square)
+}
+
+// LightRange turns on the LEDs of a range of squares; see SetLeds for
+// what the range covers.
+func (dgtboard *DgtBoard) LightRange(from chess.Sq, to chess.Sq) error {
+	return dgtboard.SetLeds(LED_ON, from, to)
+}
+
+// LightMove turns on the LEDs of the from and to squares of a move,
+// and of nothing else.
+func (dgtboard *DgtBoard) LightMove(move chess.Move) error {
+	err := dgtboard.ClearLeds()
+	if err != nil {
+		return err
+	}
+	err = dgtboard.LightSquare(move.From)
+	if err != nil {
+		return err
+	}
+	return dgtboard.LightSquare(move.To)
+}
+
+// LightSquares turns on the LEDs of some squares, and off all the
+// others.
+func (dgtboard *DgtBoard) LightSquares(squares []chess.Sq) error {
+	err := dgtboard.ClearLeds()
+	if err != nil {
+		return err
+	}
+	for _, square := range squares {
+		err = dgtboard.LightSquare(square)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClearLeds turns off every LED.
+func (dgtboard *DgtBoard) ClearLeds() error {
+	_, err := dgtboard.WriteBytes(encodeSetLeds(LED_OFF, 0, 63))
+	return err
+}
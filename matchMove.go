@@ -0,0 +1,192 @@
+package godgt
+
+import (
+	"log"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// SensorBoard returns the position as the board's sensors currently
+// see it: the last stable position, with every field update since
+// applied to it.
+func (mp *MessageProcessor) SensorBoard() [64]chess.Piece {
+	return mp.sensors
+}
+
+// MatchMove looks for the legal move which leads from the last
+// confirmed position to the position that the sensors see. Rather than
+// trying to recognise each kind of move from the pattern of lifts and
+// drops, every legal move is played out, and the resulting position
+// compared square by square with the sensors. So a capture only
+// matches once the captured piece has actually gone, castling only
+// once both king and rook are on their new squares, en passant only
+// once the captured pawn has been taken off, and a promotion only
+// matches the piece that was actually put down on the last rank.
+//
+// Castling should be done king first, as the rules say. A rook moved
+// first is just a rook move, until the king follows it; see
+// replaceLastMove.
+//
+// It returns the move and the position after it, or false if the
+// sensors don't show the result of any legal move.
+func (mp *MessageProcessor) MatchMove() (chess.Move, *chess.Board, bool) {
+	sensors := mp.SensorBoard()
+	for _, move := range mp.Board.LegalMoves() {
+		after := mp.Board.MakeMove(move)
+		if after.Piece == sensors {
+			return move, after, true
+		}
+	}
+	return chess.Move{}, nil, false
+}
+
+// processPossibleMove is called with each stable position. If the
+// sensors show a legal move, the move is applied to Board and
+// reported, and true is returned.
+//
+// Moves are only ever looked for once the pieces have settled. Until
+// then, a piece on its way somewhere (a bishop slid across several
+// squares, say) can pass through a square that would make a perfectly
+// good move on its own.
+func (mp *MessageProcessor) processPossibleMove() bool {
+	move, after, ok := mp.MatchMove()
+	if !ok {
+		return mp.replaceLastMove()
+	}
+
+	log.Println("Move accepted: " + move.San(mp.Board))
+	mp.applyMove(move, after, false)
+
+	previous := mp.Inference.State
+	mp.Inference.Confirm(after)
+	mp.reportState(previous)
+	return true
+}
+
+// applyMove makes after (the position after move) the current
+// position, and reports the move.
+func (mp *MessageProcessor) applyMove(move chess.Move, after *chess.Board, replaces bool) {
+	mp.emit(NewMoveEvent(&MoveEvent{
+		Move:       move,
+		San:        move.San(mp.Board),
+		FenBefore:  mp.Board.Fen(),
+		FenAfter:   after.Fen(),
+		Side:       mp.Board.SideToMove,
+		MoveNumber: mp.Board.MoveNr,
+		Replaces:   replaces,
+		Clock:      mp.Clock,
+		Time:       time.Now(),
+	}))
+
+	mp.history = append(mp.history, playedMove{Before: mp.Board, Move: move})
+	mp.Board = after
+	mp.clearAirState()
+}
+
+func (mp *MessageProcessor) clearAirState() {
+	mp.PiecesInTheAir = make(map[chess.Sq]chess.Piece)
+	mp.PiecesDropped = make(map[chess.Sq]chess.Piece)
+	mp.FirstPieceUp = nil
+}
+
+// replaceLastMove catches a move that the pieces settled in the middle
+// of, so that the start of it has already been accepted as a move of
+// its own: a piece slid across several squares and paused on the way,
+// or castling done rook first. If the sensors show the same piece
+// having gone on from the same square instead, or the king having
+// joined the rook, the new move is reported in place of the last one.
+func (mp *MessageProcessor) replaceLastMove() bool {
+	if len(mp.history) == 0 {
+		return false
+	}
+	last := mp.history[len(mp.history)-1]
+	before := last.Before
+	rook := before.Piece[last.Move.From] == chess.WR ||
+		before.Piece[last.Move.From] == chess.BR
+
+	for _, move := range before.LegalMoves() {
+		if move == last.Move {
+			continue
+		}
+		// The rook has moved, so the only king move that can
+		// match is castling.
+		king := before.Piece[move.From] == chess.WK ||
+			before.Piece[move.From] == chess.BK
+		if move.From != last.Move.From && !(rook && king) {
+			continue
+		}
+		after := before.MakeMove(move)
+		if after.Piece != mp.sensors {
+			continue
+		}
+		log.Println("Move replaced: " + move.San(before))
+		mp.history = mp.history[:len(mp.history)-1]
+		mp.Board = before
+		mp.applyMove(move, after, true)
+
+		previous := mp.Inference.State
+		mp.Inference.Confirm(after)
+		mp.reportState(previous)
+		return true
+	}
+	return false
+}
+
+// processNonMove is called when the pieces have settled in a position
+// that no legal moves lead to. It reports an illegal move if every
+// piece of the side to move that was picked up has been put down
+// again, and it doesn't
+// look like the first half of a legal move (such as a king that
+// still needs its rook moving to complete castling, or a pawn that
+// has captured en passant but whose victim is still on the board).
+// Otherwise the move is probably still in progress, and we keep
+// waiting.
+func (mp *MessageProcessor) processNonMove() {
+	var from, to []chess.Sq
+	for square, piece := range mp.PiecesInTheAir {
+		if piece.Color() == mp.Board.SideToMove {
+			from = append(from, square)
+		}
+	}
+	for square, piece := range mp.PiecesDropped {
+		if piece.Color() == mp.Board.SideToMove {
+			to = append(to, square)
+		}
+	}
+	if len(from) == 0 || len(from) != len(to) {
+		return
+	}
+
+	var attempted string
+	if len(from) == 1 {
+		if mp.isMoveInProgress(from[0], to[0]) {
+			return
+		}
+		attempted = from[0].String() + to[0].String()
+	} else {
+		attempted = mp.AirState()
+	}
+	log.Println("Not a legal move: " + attempted)
+	mp.emit(NewIllegalMoveEvent(&IllegalMoveEvent{
+		Move:   attempted,
+		Reason: "no legal move leads to this position",
+		Fen:    mp.Board.Fen(),
+		Time:   time.Now(),
+	}))
+}
+
+// isMoveInProgress is true if some legal move takes the piece on from
+// to to, but also moves or removes other pieces.
+func (mp *MessageProcessor) isMoveInProgress(from chess.Sq, to chess.Sq) bool {
+	for _, move := range mp.Board.LegalMoves() {
+		if move.From != from {
+			continue
+		}
+		after := mp.Board.MakeMove(move)
+		if after.Piece[to] == mp.PiecesDropped[to] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,200 @@
+package godgt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/malbrecht/chess"
+)
+
+const (
+	castlingFen      = "r3k2r/pppppppp/8/8/8/8/PPPPPPPP/R3K2R w KQkq - 0 1"
+	captureFen       = "rnbqkbnr/ppp1pppp/8/3p4/4P3/8/PPPP1PPP/RNBQKBNR w KQkq - 0 2"
+	enPassantFen     = "rnbqkbnr/ppp1p1pp/8/3pPp2/8/8/PPPP1PPP/RNBQKBNR w KQkq f6 0 3"
+	promotionFen     = "2r5/1P6/8/8/8/8/k7/4K3 w - - 0 1"
+	blackCastlingFen = "r3k2r/pppppppp/8/8/8/8/PPPPPPPP/R3K2R b KQkq - 0 1"
+	slideFen         = "rnbqkbnr/pppp1ppp/8/4p3/4P3/8/PPPP1PPP/RNBQKBNR w KQkq e6 0 2"
+)
+
+// The field updates are given as squares for pieces being lifted, and
+// as a piece letter and a square for pieces being put down; "|" is the
+// pieces settling, and a stable position being seen. The events
+// expected are given as the moves reported, with "=" in front of a
+// move that replaces the one before it, and "illegal" for an illegal
+// move.
+var matchMoveTests = []struct {
+	name    string
+	fen     string
+	updates string
+	events  string
+}{
+	{"pawn push", StartingFen, "e2 Pe4 |", "e4"},
+	{"knight move", StartingFen, "g1 Nf3 |", "Nf3"},
+	{"black move", "rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq e3 0 1",
+		"c7 pc5 |", "c5"},
+	{"move not settled yet", StartingFen, "e2 Pe4", ""},
+
+	{"capture, capturing piece lifted first", captureFen, "e4 d5 Pd5 |", "exd5"},
+	{"capture, captured piece lifted first", captureFen, "d5 e4 Pd5 |", "exd5"},
+	{"capture, pieces swapped directly", captureFen, "e4 Pd5 |", "exd5"},
+
+	{"O-O, king first", castlingFen, "e1 Kg1 h1 Rf1 |", "O-O"},
+	{"O-O, rook first", castlingFen, "h1 Rf1 e1 Kg1 |", "O-O"},
+	{"O-O, rook first, settling in between", castlingFen,
+		"h1 Rf1 | e1 Kg1 |", "Rf1 =O-O"},
+	{"rook move on its own", castlingFen, "h1 Rf1 |", "Rf1"},
+	{"O-O-O, king first", castlingFen, "e1 Kc1 a1 Rd1 |", "O-O-O"},
+	{"O-O-O, rook first, settling in between", castlingFen,
+		"a1 Rd1 | e1 Kc1 |", "Rd1 =O-O-O"},
+	{"black O-O, king first", blackCastlingFen, "e8 kg8 h8 rf8 |", "O-O"},
+	{"black O-O-O, rook first, settling in between", blackCastlingFen,
+		"a8 rd8 | e8 kc8 |", "Rd8 =O-O-O"},
+
+	{"piece slid over other squares", slideFen, "f1 Be2 e2 Bd3 d3 Bc4 |", "Bc4"},
+	{"piece slid, pausing on the way", slideFen,
+		"f1 Be2 | e2 Bd3 | d3 Bc4 |", "Be2 =Bd3 =Bc4"},
+	{"piece slid, then the reply", slideFen,
+		"f1 Be2 | e2 Bc4 | g8 nf6 |", "Be2 =Bc4 Nf6"},
+
+	{"en passant, capturing pawn first", enPassantFen, "e5 Pf6 f5 |", "exf6"},
+	{"en passant, captured pawn first", enPassantFen, "f5 e5 Pf6 |", "exf6"},
+
+	{"promotion", promotionFen, "b7 Qb8 |", "b8=Q"},
+	{"under-promotion to knight", promotionFen, "b7 Nb8 |", "b8=N"},
+	{"under-promotion to rook", promotionFen, "b7 Rb8 |", "b8=R"},
+	{"promotion with capture", promotionFen, "b7 c8 Qc8 |", "bxc8=Q"},
+
+	{"piece still in the air", StartingFen, "g1 |", ""},
+	{"capture, capturing piece still in the air", captureFen, "e4 d5 |", ""},
+	{"castling, rook not moved yet", castlingFen, "e1 Kg1 |", ""},
+	{"en passant, captured pawn still on the board", enPassantFen, "e5 Pf6 |", ""},
+	{"promotion, pawn not swapped", promotionFen, "b7 Pb8 |", "illegal"},
+	{"two pieces moved at once", StartingFen, "g1 b1 Nf3 Nc3 |", "illegal"},
+}
+
+func TestMatchMove(t *testing.T) {
+	for _, test := range matchMoveTests {
+		t.Run(test.name, func(t *testing.T) {
+			board, err := chess.ParseFen(test.fen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mp := NewMessageProcessor()
+			mp.sensors = board.Piece
+			mp.startGame(board, false)
+
+			processTestUpdates(t, mp, test.updates)
+
+			var events []string
+			var lastMove *MoveEvent
+			for len(mp.Events) > 0 {
+				event := <-mp.Events
+				switch {
+				case event.MoveEvent != nil && event.MoveEvent.Replaces:
+					events = append(events, "="+event.MoveEvent.San)
+					lastMove = event.MoveEvent
+				case event.MoveEvent != nil:
+					events = append(events, event.MoveEvent.San)
+					lastMove = event.MoveEvent
+				case event.IllegalMoveEvent != nil:
+					events = append(events, "illegal")
+				}
+			}
+			if got := strings.Join(events, " "); got != test.events {
+				t.Fatalf("got %q, want %q", got, test.events)
+			}
+
+			if lastMove == nil {
+				if mp.Board != board {
+					t.Fatalf("position changed to %s", mp.Board.Fen())
+				}
+				return
+			}
+			if mp.Board.Piece != mp.SensorBoard() {
+				t.Fatalf("position %s doesn't match the sensors", mp.Board.Fen())
+			}
+			if lastMove.FenAfter != mp.Board.Fen() {
+				t.Fatalf("move left %s, but position is %s",
+					lastMove.FenAfter, mp.Board.Fen())
+			}
+		})
+	}
+}
+
+// processTestUpdates feeds the updates, in the form used by
+// matchMoveTests, to mp.
+func processTestUpdates(t *testing.T, mp *MessageProcessor, updates string) {
+	for _, update := range strings.Fields(updates) {
+		if update == "|" {
+			board := *mp.Board
+			board.Piece = mp.SensorBoard()
+			mp.ProcessMessage(NewStablePositionMessage(NewStablePosition(&board)))
+			continue
+		}
+		mp.ProcessMessage(NewFieldUpdateMessage(parseTestUpdate(t, update)))
+	}
+}
+
+// parseTestUpdate turns "e2" into a lift from e2, and "Pe4" into a white
+// pawn put down on e4.
+func parseTestUpdate(t *testing.T, update string) *FieldUpdate {
+	piece := chess.NoPiece
+	if len(update) == 3 {
+		piece = chess.Piece(strings.IndexByte(chess.PieceLetters, update[0]))
+		update = update[1:]
+	}
+	if len(update) != 2 || piece < 0 {
+		t.Fatalf("bad update %q", update)
+	}
+	square := chess.Square(int(update[0]-'a'), int(update[1]-'1'))
+	return NewFieldUpdate(square, piece)
+}
+
+// The board's stored history has no stable positions in it; the
+// replay has to work out where the pieces settled.
+var eeReplayTests = []struct {
+	name    string
+	updates string
+	sans    string
+}{
+	{"moves", "e2 Pe4 e7 pe5 g1 Nf3", "e4 e5 Nf3"},
+	{"capture", "e2 Pe4 d7 pd5 e4 d5 Pd5 b8 nc6", "e4 d5 exd5 Nc6"},
+	{"en passant, captured pawn last", "e2 Pe4 a7 pa6 e4 Pe5 d7 pd5 e5 Pd6 d5 a6 pa5",
+		"e4 a6 e5 d5 exd6 a5"},
+	{"O-O, king first", "e2 Pe4 e7 pe5 g1 Nf3 b8 nc6 f1 Bc4 g8 nf6 e1 Kg1 h1 Rf1",
+		"e4 e5 Nf3 Nc6 Bc4 Nf6 O-O"},
+	{"piece slid over other squares", "e2 Pe4 e7 pe5 f1 Be2 e2 Bd3 d3 Bc4 b8 nc6",
+		"e4 e5 Bc4 Nc6"},
+}
+
+func TestEEMovesReplay(t *testing.T) {
+	for _, test := range eeReplayTests {
+		t.Run(test.name, func(t *testing.T) {
+			board, err := chess.ParseFen(StartingFen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			events := []*EEEvent{{Type: EEPowerUp, Board: board}}
+			for _, update := range strings.Fields(test.updates) {
+				events = append(events, &EEEvent{
+					Type:        EEFieldChange,
+					FieldUpdate: parseTestUpdate(t, update),
+				})
+			}
+
+			mp := NewMessageProcessor()
+			mp.ProcessMessage(NewEEMovesMessage(NewEEMoves(events, false)))
+
+			var sans []string
+			for len(mp.Events) > 0 {
+				event := <-mp.Events
+				if event.MoveEvent != nil {
+					sans = append(sans, event.MoveEvent.San)
+				}
+			}
+			if got := strings.Join(sans, " "); got != test.sans {
+				t.Fatalf("got %q, want %q", got, test.sans)
+			}
+		})
+	}
+}
@@ -1,12 +1,21 @@
 package godgt
 
-// Message is really just a way to multiplex three different types of message
-// onto a single channel.
+// Message is really just a way to multiplex several different types of
+// message onto a single channel.
 type Message struct {
-	BoardUpdate *BoardUpdate
-	FieldUpdate *FieldUpdate
-	TimeUpdate  *TimeUpdate
-	InfoUpdate  *InfoUpdate
+	BoardUpdate      *BoardUpdate
+	FieldUpdate      *FieldUpdate
+	TimeUpdate       *TimeUpdate
+	InfoUpdate       *InfoUpdate
+	ClockAck         *ClockAck
+	ClockButtonPress *ClockButtonPress
+	EEMoves          *EEMoves
+	StablePosition   *StablePosition
+	ResultSignal     *ResultSignal
+	BatteryStatus    *BatteryStatus
+
+	DraughtsBoardUpdate *DraughtsBoardUpdate
+	DraughtsFieldUpdate *DraughtsFieldUpdate
 }
 
 // Note, not implementing Stringer interface as you can't implement
@@ -20,6 +29,22 @@ func (m *Message) ToString() string {
 		return m.TimeUpdate.ToString()
 	} else if m.InfoUpdate != nil {
 		return m.InfoUpdate.ToString()
+	} else if m.ClockAck != nil {
+		return m.ClockAck.ToString()
+	} else if m.ClockButtonPress != nil {
+		return m.ClockButtonPress.ToString()
+	} else if m.EEMoves != nil {
+		return m.EEMoves.ToString()
+	} else if m.StablePosition != nil {
+		return m.StablePosition.ToString()
+	} else if m.ResultSignal != nil {
+		return m.ResultSignal.ToString()
+	} else if m.BatteryStatus != nil {
+		return m.BatteryStatus.ToString()
+	} else if m.DraughtsBoardUpdate != nil {
+		return m.DraughtsBoardUpdate.ToString()
+	} else if m.DraughtsFieldUpdate != nil {
+		return m.DraughtsFieldUpdate.ToString()
 	} else {
 		return ""
 	}
@@ -43,9 +68,56 @@ func NewTimeUpdateMessage(timeUpdate *TimeUpdate) *Message {
 	}
 }
 
-func NewInfoUpdateMessage(info string) *Message {
-	infoUpdate := NewInfoUpdate(info)
+func NewInfoUpdateMessage(infoUpdate *InfoUpdate) *Message {
 	return &Message{
 		InfoUpdate: infoUpdate,
 	}
 }
+
+func NewClockAckMessage(clockAck *ClockAck) *Message {
+	return &Message{
+		ClockAck: clockAck,
+	}
+}
+
+func NewClockButtonPressMessage(clockButtonPress *ClockButtonPress) *Message {
+	return &Message{
+		ClockButtonPress: clockButtonPress,
+	}
+}
+
+func NewEEMovesMessage(eeMoves *EEMoves) *Message {
+	return &Message{
+		EEMoves: eeMoves,
+	}
+}
+
+func NewStablePositionMessage(stablePosition *StablePosition) *Message {
+	return &Message{
+		StablePosition: stablePosition,
+	}
+}
+
+func NewResultSignalMessage(resultSignal *ResultSignal) *Message {
+	return &Message{
+		ResultSignal: resultSignal,
+	}
+}
+
+func NewBatteryStatusMessage(batteryStatus *BatteryStatus) *Message {
+	return &Message{
+		BatteryStatus: batteryStatus,
+	}
+}
+
+func NewDraughtsBoardUpdateMessage(draughtsBoardUpdate *DraughtsBoardUpdate) *Message {
+	return &Message{
+		DraughtsBoardUpdate: draughtsBoardUpdate,
+	}
+}
+
+func NewDraughtsFieldUpdateMessage(draughtsFieldUpdate *DraughtsFieldUpdate) *Message {
+	return &Message{
+		DraughtsFieldUpdate: draughtsFieldUpdate,
+	}
+}
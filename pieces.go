@@ -1,8 +1,11 @@
 package godgt
 
 import (
+	"errors"
 	"strconv"
 	"strings"
+
+	"github.com/malbrecht/chess"
 )
 
 // CharToUnicode converts a simple "FEN" character (that is, Q=white queen,
@@ -105,3 +108,68 @@ func UnicodeBoardFromFen(fen string) []string {
 	}
 	return unicodeRows
 }
+
+// PieceFromFenChar converts a single FEN character (Q=white queen,
+// p=black pawn, etc) into a chess.Piece, returning chess.NoPiece if
+// the character isn't a piece.
+func PieceFromFenChar(fenChar byte) chess.Piece {
+	switch fenChar {
+	case 'P':
+		return chess.WP
+	case 'N':
+		return chess.WN
+	case 'B':
+		return chess.WB
+	case 'R':
+		return chess.WR
+	case 'Q':
+		return chess.WQ
+	case 'K':
+		return chess.WK
+	case 'p':
+		return chess.BP
+	case 'n':
+		return chess.BN
+	case 'b':
+		return chess.BB
+	case 'r':
+		return chess.BR
+	case 'q':
+		return chess.BQ
+	case 'k':
+		return chess.BK
+	default:
+		return chess.NoPiece
+	}
+}
+
+// ParseSquare converts an algebraic square name such as "e4" into a
+// chess.Sq.
+func ParseSquare(name string) (chess.Sq, error) {
+	if len(name) != 2 {
+		return chess.NoSquare, errors.New("Bad square: " + name)
+	}
+	file := int(name[0]) - 'a'
+	rank := int(name[1]) - '1'
+	if file < 0 || file > 7 || rank < 0 || rank > 7 {
+		return chess.NoSquare, errors.New("Bad square: " + name)
+	}
+	return chess.Square(file, rank), nil
+}
+
+// ParsePieceSquare parses a square optionally preceded by a FEN piece
+// letter, such as "e4", "Bf1" or "qd8". If no piece is given, the
+// returned piece is chess.NoPiece.
+func ParsePieceSquare(text string) (chess.Piece, chess.Sq, error) {
+	if len(text) == 3 {
+		piece := PieceFromFenChar(text[0])
+		if piece == chess.NoPiece {
+			return chess.NoPiece, chess.NoSquare,
+				errors.New("Bad piece: " + text)
+		}
+		square, err := ParseSquare(text[1:])
+		return piece, square, err
+	}
+	square, err := ParseSquare(text)
+	return chess.NoPiece, square, err
+}
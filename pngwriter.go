@@ -9,8 +9,13 @@ import (
 	"io"
 	"log"
 	"os"
+
+	"github.com/malbrecht/chess"
 )
 
+// HighlightColour is drawn (translucently) over highlighted squares.
+var HighlightColour = color.NRGBA{255, 0, 0, 96}
+
 func GetFigurineName(fenChar string) string {
 	// JUST for pieces; we deal with other stuff later,
 	// including empty squares and stuff.
@@ -88,6 +93,13 @@ func WritePng(fen string, size int, filename string) {
 }
 
 func WriteBoardAsPng(fen string, size int, w io.Writer) {
+	WriteBoardAsPngWithHighlights(fen, size, nil, w)
+}
+
+// WriteBoardAsPngWithHighlights is WriteBoardAsPng, with some squares
+// picked out in HighlightColour; for example, the squares that need
+// putting right to get back to the position.
+func WriteBoardAsPngWithHighlights(fen string, size int, highlights []chess.Sq, w io.Writer) {
 	// Get a handle onto the local embedded assets
 	fs := FS(false)
 	output := image.NewRGBA(image.Rect(0, 0, size*8, size*8))
@@ -121,5 +133,13 @@ func WriteBoardAsPng(fen string, size int, w io.Writer) {
 			draw.Draw(output, r, oneImage, image.ZP, draw.Over)
 		}
 	}
+	for _, square := range highlights {
+		// Rows are counted from the top, i.e. from the 8th rank.
+		x := size * square.File()
+		y := size * (7 - square.Rank())
+		r := image.Rect(x, y, x+size, y+size)
+		draw.Draw(output, r, &image.Uniform{HighlightColour},
+			image.ZP, draw.Over)
+	}
 	png.Encode(w, output)
 }
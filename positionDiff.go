@@ -0,0 +1,112 @@
+package godgt
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/malbrecht/chess"
+)
+
+// SquareFix says what needs doing to a square to restore a position.
+type SquareFix int
+
+const (
+	// A piece needs taking off the square.
+	FixRemove SquareFix = iota
+	// A piece needs putting on the (empty) square.
+	FixAdd
+	// The piece on the square needs swapping for another.
+	FixReplace
+)
+
+// SquareDiff is a square where the pieces on the board don't match
+// the position they should be in.
+type SquareDiff struct {
+	Square chess.Sq
+	Fix    SquareFix
+
+	// What should be on the square, and what is.
+	Expected chess.Piece
+	Actual   chess.Piece
+}
+
+func (sd SquareDiff) ToString() string {
+	switch sd.Fix {
+	case FixRemove:
+		return "remove " + fmtpsq(sd.Actual, sd.Square)
+	case FixAdd:
+		return "put " + fmtpsq(sd.Expected, sd.Square)
+	default:
+		return fmt.Sprintf("replace %s with %c",
+			fmtpsq(sd.Actual, sd.Square), chess.PieceLetters[sd.Expected])
+	}
+}
+
+// ClockText describes the fix in eight characters or less, for the
+// clock's display: for example "e2 +P", "f3 -n" or "c3 n>N".
+func (sd SquareDiff) ClockText() string {
+	switch sd.Fix {
+	case FixRemove:
+		return fmt.Sprintf("%s -%c", sd.Square, chess.PieceLetters[sd.Actual])
+	case FixAdd:
+		return fmt.Sprintf("%s +%c", sd.Square, chess.PieceLetters[sd.Expected])
+	default:
+		return fmt.Sprintf("%s %c>%c", sd.Square,
+			chess.PieceLetters[sd.Actual], chess.PieceLetters[sd.Expected])
+	}
+}
+
+// PositionDiff lists the squares that need changing to get from one
+// arrangement of pieces to another, in square order.
+type PositionDiff []SquareDiff
+
+// DiffPosition compares what the sensors show with the position that
+// is expected, and says what needs to be done to each square to put
+// the pieces back.
+func DiffPosition(expected, actual [64]chess.Piece) PositionDiff {
+	var diff PositionDiff
+	for s := range expected {
+		if expected[s] == actual[s] {
+			continue
+		}
+		sd := SquareDiff{
+			Square:   chess.Sq(s),
+			Fix:      FixReplace,
+			Expected: expected[s],
+			Actual:   actual[s],
+		}
+		if expected[s] == chess.NoPiece {
+			sd.Fix = FixRemove
+		} else if actual[s] == chess.NoPiece {
+			sd.Fix = FixAdd
+		}
+		diff = append(diff, sd)
+	}
+	return diff
+}
+
+// Squares returns the squares that need changing.
+func (pd PositionDiff) Squares() []chess.Sq {
+	var squares []chess.Sq
+	for _, sd := range pd {
+		squares = append(squares, sd.Square)
+	}
+	return squares
+}
+
+func (pd PositionDiff) ToString() string {
+	var fixes []string
+	for _, sd := range pd {
+		fixes = append(fixes, sd.ToString())
+	}
+	return strings.Join(fixes, ", ")
+}
+
+// ClockText describes the first fix to make, for the clock's display.
+// Once it's been made, the next one can be shown.
+func (pd PositionDiff) ClockText() string {
+	if len(pd) == 0 {
+		return ""
+	}
+	return pd[0].ClockText()
+}
@@ -0,0 +1,177 @@
+package godgt
+
+import (
+	"log"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// performGesture carries out the actions that the MessageProcessor
+// knows how to, and reports the gesture in a SignalEvent.
+func (mp *MessageProcessor) performGesture(gesture *Gesture, piece chess.Piece, square chess.Sq) {
+	description := gesture.ActionString()
+
+	switch gesture.Action {
+	case ActionWhiteToMove:
+		mp.Board.SideToMove = chess.White
+	case ActionBlackToMove:
+		mp.Board.SideToMove = chess.Black
+	case ActionToggleCastling:
+		description = mp.toggleCastling(piece, square)
+		if description == "" {
+			log.Println("Couldn't decode special signal: " + fmtpsq(piece, square))
+			return
+		}
+	case ActionTakeback:
+		if !mp.takeBackGesture() {
+			log.Println("Nothing to take back")
+			return
+		}
+	}
+
+	log.Println("Special signal: " + description)
+	mp.emit(NewSignalEvent(&SignalEvent{
+		Square:      square,
+		Piece:       piece,
+		Description: description,
+		Action:      gesture.Action,
+		Argument:    gesture.Argument,
+		Time:        time.Now(),
+	}))
+
+	switch gesture.Action {
+	case ActionWhiteToMove, ActionBlackToMove:
+		if mp.AwaitingSetup {
+			mp.startGame(mp.Board, false)
+		}
+	case ActionNewGame:
+		// As with a result, the next stable position is the start
+		// of the new game.
+		mp.Board = nil
+		mp.Inference = nil
+		mp.AwaitingSetup = false
+		mp.clearAirState()
+	}
+}
+
+// toggleCastling turns castling with the rook on a square on or off,
+// and says which.
+func (mp *MessageProcessor) toggleCastling(rook chess.Piece, square chess.Sq) string {
+	var color string
+	var king chess.Piece
+	var oo, ooo int
+	switch rook {
+	case chess.WR:
+		color, king, oo, ooo = "White", chess.WK, chess.WhiteOO, chess.WhiteOOO
+	case chess.BR:
+		color, king, oo, ooo = "Black", chess.BK, chess.BlackOO, chess.BlackOOO
+	default:
+		return ""
+	}
+
+	// The rook castles on whichever side of the king it's on.
+	index := -1
+	for s, piece := range mp.Board.Piece {
+		if piece == king && chess.Sq(s).Rank() == square.Rank() {
+			if square.File() < chess.Sq(s).File() {
+				index = ooo
+			} else {
+				index = oo
+			}
+		}
+	}
+	if index < 0 {
+		return ""
+	}
+
+	side := "kingside"
+	if index == ooo {
+		side = "queenside"
+	}
+	if mp.Board.CastleSq[index] == square {
+		mp.Board.CastleSq[index] = chess.NoSquare
+		return color + " may NOT castle " + side
+	}
+	mp.Board.CastleSq[index] = square
+	return color + " may castle " + side
+}
+
+// processPlaceGesture checks whether a piece put down is a GesturePlace
+// gesture rather than part of a move: the square has to be empty, and
+// no piece of the same kind can be in the air. If it is a gesture, it
+// is acted on, and the piece is otherwise ignored; taking it away again
+// is just an empty square being lifted from.
+func (mp *MessageProcessor) processPlaceGesture(fieldUpdate *FieldUpdate) bool {
+	gesture := mp.Gestures.Match(GesturePlace, fieldUpdate.Piece, fieldUpdate.Square)
+	if gesture == nil {
+		return false
+	}
+	if mp.Board.Piece[fieldUpdate.Square] != chess.NoPiece {
+		return false
+	}
+	for _, piece := range mp.PiecesInTheAir {
+		if piece == fieldUpdate.Piece {
+			return false
+		}
+	}
+	mp.performGesture(gesture, fieldUpdate.Piece, fieldUpdate.Square)
+	return true
+}
+
+// isShowingGesture is true if the only differences between the sensors
+// and the position are gesture pieces that have been put down.
+func (mp *MessageProcessor) isShowingGesture() bool {
+	differences := 0
+	for s, piece := range mp.sensors {
+		square := chess.Sq(s)
+		if piece == mp.Board.Piece[square] {
+			continue
+		}
+		if mp.Board.Piece[square] != chess.NoPiece ||
+			mp.Gestures.Match(GesturePlace, piece, square) == nil {
+			return false
+		}
+		differences++
+	}
+	return differences > 0
+}
+
+// processStableGestures looks for gestures that can only be seen in a
+// complete view of the board, such as the kings being swapped. It
+// returns true if the sensors are showing a gesture, in which case they
+// shouldn't be treated as a position.
+func (mp *MessageProcessor) processStableGestures() bool {
+	if mp.isShowingGesture() {
+		return true
+	}
+
+	whiteKing, blackKing := chess.NoSquare, chess.NoSquare
+	for s, piece := range mp.Board.Piece {
+		switch piece {
+		case chess.WK:
+			whiteKing = chess.Sq(s)
+		case chess.BK:
+			blackKing = chess.Sq(s)
+		}
+	}
+	if whiteKing == chess.NoSquare || blackKing == chess.NoSquare {
+		return false
+	}
+
+	swapped := mp.Board.Piece
+	swapped[whiteKing], swapped[blackKing] = chess.BK, chess.WK
+	if mp.sensors != swapped {
+		mp.kingsSwapped = false
+		return false
+	}
+
+	if !mp.kingsSwapped {
+		mp.kingsSwapped = true
+		gesture := mp.Gestures.Match(GestureSwapKings, chess.NoPiece, chess.NoSquare)
+		if gesture != nil {
+			mp.performGesture(gesture, chess.WK, whiteKing)
+		}
+	}
+	return true
+}
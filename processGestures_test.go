@@ -0,0 +1,71 @@
+package godgt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/malbrecht/chess"
+)
+
+// The updates are given as for matchMoveTests, starting from the
+// standard starting position, with these gestures. The events
+// expected are the moves, "takeback" for a move taken back, and
+// "diverged" each time the players are told to put the pieces back.
+var takebackGestureTests = []struct {
+	name    string
+	updates string
+	events  string
+	fen     string
+}{
+	{"move taken back", "e2 Pe4 | d8 qd8 | e4 Pe2 |", "e4 takeback diverged",
+		StartingFen},
+	{"move taken back, then another played", "e2 Pe4 | d8 qd8 | e4 Pe2 | d2 Pd4 |",
+		"e4 takeback diverged d4",
+		"rnbqkbnr/pppppppp/8/8/3P4/8/PPP1PPPP/RNBQKBNR b KQkq d3 0 1"},
+	{"move taken back with a spare piece", "e2 Pe4 | qd4 | d4 | e4 Pe2 |",
+		"e4 takeback diverged diverged", StartingFen},
+	{"nothing to take back", "d8 qd8 |", "", StartingFen},
+}
+
+func TestTakebackGesture(t *testing.T) {
+	gestures, err := ParseGestures(strings.NewReader(
+		"replace qd8 => takeback\nplace qd4 => takeback\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range takebackGestureTests {
+		t.Run(test.name, func(t *testing.T) {
+			board, err := chess.ParseFen(StartingFen)
+			if err != nil {
+				t.Fatal(err)
+			}
+			mp := NewMessageProcessor()
+			mp.Gestures = gestures
+			mp.sensors = board.Piece
+			mp.startGame(board, false)
+
+			processTestUpdates(t, mp, test.updates)
+
+			var events []string
+			for len(mp.Events) > 0 {
+				event := <-mp.Events
+				switch {
+				case event.MoveEvent != nil:
+					events = append(events, event.MoveEvent.San)
+				case event.TakebackEvent != nil:
+					events = append(events, "takeback")
+				case event.StateEvent != nil &&
+					event.StateEvent.State == InferenceDiverged:
+					events = append(events, "diverged")
+				}
+			}
+			if got := strings.Join(events, " "); got != test.events {
+				t.Fatalf("got %q, want %q", got, test.events)
+			}
+			if mp.Board.Fen() != test.fen {
+				t.Fatalf("got position %s, want %s", mp.Board.Fen(), test.fen)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/malbrecht/chess"
 )
@@ -23,13 +24,67 @@ type MessageProcessor struct {
 	PiecesInTheAir map[chess.Sq]chess.Piece
 	PiecesDropped  map[chess.Sq]chess.Piece
 
-	// Need to add a move channel here.
+	// The most recent clock reading, if we've had one.
+	Clock *TimeUpdate
+
+	// What the sensors currently show, kept up to date from every
+	// field update and board dump, regardless of whether they make
+	// any sense.
+	sensors [64]chess.Piece
+
+	// Used when no single move explains a stable position, to catch
+	// up or recover.
+	Inference *InferenceEngine
+
+	// The moves played in the current line of the game, so that we
+	// can tell when some of them have been taken back, or when the
+	// last was only the start of a move.
+	history []playedMove
+
+	// The last mismatch reported, so that we only report it again
+	// when it changes.
+	reportedDiff string
+
+	// Set if the game is being played under Chess960 rules, which
+	// is the case if it started from a Chess960 position.
+	Chess960 bool
+
+	// Set while we're waiting for the players to confirm a setup
+	// that isn't a starting position; see setup.go.
+	AwaitingSetup bool
+
+	// The gestures that the players can make with the pieces.
+	Gestures GestureTable
+
+	// Set while the kings are swapped, so that the gesture is only
+	// acted on once.
+	kingsSwapped bool
+
+	// Set after a move has been taken back with a gesture, until the
+	// pieces have been put back to match.
+	awaitingTakeback bool
+
+	// Moves and other things worth knowing about are sent here.
+	// If nobody is reading them and the channel fills up, new
+	// events are dropped (and logged) rather than blocking the
+	// processing of messages from the board.
+	Events chan *Event
 }
 
 func NewMessageProcessor() *MessageProcessor {
 	return &MessageProcessor{
 		PiecesInTheAir: make(map[chess.Sq]chess.Piece),
 		PiecesDropped:  make(map[chess.Sq]chess.Piece),
+		Events:         make(chan *Event, 1024),
+		Gestures:       DefaultGestures(),
+	}
+}
+
+func (mp *MessageProcessor) emit(event *Event) {
+	select {
+	case mp.Events <- event:
+	default:
+		log.Println("Event channel full; dropping " + event.ToString())
 	}
 }
 
@@ -67,9 +122,23 @@ func (mp *MessageProcessor) ProcessMessage(m *Message) {
 		mp.processTimeUpdate(m)
 	} else if m.InfoUpdate != nil {
 		mp.processInfoUpdate(m)
+	} else if m.ClockAck != nil {
+		// Acks are picked up by SendClockCommand; nothing to do.
+	} else if m.ClockButtonPress != nil {
+		mp.processClockButtonPress(m)
+	} else if m.EEMoves != nil {
+		mp.processEEMoves(m)
+	} else if m.StablePosition != nil {
+		mp.processStablePosition(m)
+	} else if m.ResultSignal != nil {
+		mp.processResultSignal(m)
+	} else if m.BatteryStatus != nil {
+		mp.processBatteryStatus(m)
+	} else if m.DraughtsBoardUpdate != nil || m.DraughtsFieldUpdate != nil {
+		// A draughts board; there's no chess game to follow.
+		log.Println("Ignoring draughts message: " + m.ToString())
 	} else {
-		// Panic? Ignore?
-		panic("Received bad message.")
+		log.Println("Ignoring unknown message: " + m.ToString())
 	}
 }
 
@@ -79,20 +148,30 @@ func (mp *MessageProcessor) processBoardUpdate(m *Message) {
 	// updates are relative to it. Note that we can configure non-starting
 	// positions using special signalling moves from the board.
 	if mp.Board == nil {
-		mp.Board = m.BoardUpdate.Board
 		log.Println("Received initial board update.")
-		log.Println(mp.Board.Fen())
+		mp.startPosition(m.BoardUpdate.Board)
 	} else {
-		// In future, maybe allow special coded moves to force
-		// a board update so we can be sure that our board is
-		// correct.
-		log.Printf("Ignoring board update.")
+		// A dump is the most reliable view of the sensors we can
+		// get, so it's a good time to check that we're still in
+		// step with the board.
+		mp.processStableBoard(m.BoardUpdate.Board)
 	}
 }
 
 func (mp *MessageProcessor) processFieldUpdate(m *Message) {
 	fieldUpdate := m.FieldUpdate
 
+	if mp.Board == nil {
+		// Without a position to start from, there's nothing to
+		// compare the update to.
+		log.Println("Ignoring field update before initial board update.")
+		return
+	}
+
+	mp.sensors[fieldUpdate.Square] = fieldUpdate.Piece
+
+	// Lifts and drops are only tracked here, for the gestures; moves
+	// wait for the pieces to settle (see processStableBoard).
 	if fieldUpdate.Piece == chess.NoPiece {
 		mp.processPieceLift(fieldUpdate)
 	} else {
@@ -100,6 +179,144 @@ func (mp *MessageProcessor) processFieldUpdate(m *Message) {
 	}
 }
 
+func (mp *MessageProcessor) processStablePosition(m *Message) {
+	// Treat it just like a board update.
+	mp.processBoardUpdate(NewBoardUpdateMessage(
+		NewBoardUpdate(m.StablePosition.Board)))
+}
+
+// processStableBoard deals with a position that we can trust the
+// sensors about, once the game has started.
+func (mp *MessageProcessor) processStableBoard(board *chess.Board) {
+	mp.sensors = board.Piece
+
+	if mp.AwaitingSetup {
+		// The players are still arranging the pieces.
+		if board.Piece != mp.Board.Piece {
+			mp.startPosition(board)
+		}
+		return
+	}
+
+	if mp.processStableGestures() {
+		return
+	}
+
+	if mp.Board.Piece != board.Piece && mp.takeback() {
+		return
+	}
+
+	if isStandardStart(board.Piece) && mp.Board.Piece != board.Piece {
+		// The pieces have been set up for a new game.
+		mp.startPosition(board)
+		return
+	}
+
+	if mp.awaitingTakeback {
+		if board.Piece != mp.Board.Piece {
+			// The pieces still show the move taken back.
+			mp.reportState(mp.Inference.State)
+			return
+		}
+		mp.awaitingTakeback = false
+	}
+
+	if mp.Board.Piece != board.Piece && mp.processPossibleMove() {
+		return
+	}
+
+	mp.infer()
+
+	if mp.Inference.State == InferenceDiverged {
+		if isChess960Start(board.Piece) {
+			mp.startPosition(board)
+			return
+		}
+		mp.processNonMove()
+	}
+}
+
+// processResultSignal ends the game. The next stable position from the
+// board (once the magic piece has been taken away again) is treated
+// like the very first board update: the start of a new game.
+func (mp *MessageProcessor) processResultSignal(m *Message) {
+	if mp.Board == nil {
+		// No game in progress; perhaps the magic piece is still
+		// on the board.
+		return
+	}
+	log.Println("Game over: " + m.ResultSignal.Result)
+	mp.emit(NewResultEvent(&ResultEvent{
+		Result: m.ResultSignal.Result,
+		Fen:    mp.Board.Fen(),
+		Time:   time.Now(),
+	}))
+	mp.Board = nil
+	mp.Inference = nil
+	mp.clearAirState()
+}
+
+// infer hands the current sensor snapshot to the inference engine,
+// and applies any moves that it finds. This catches up when the
+// pieces have settled more than one move on from the last position,
+// for example after the board was disconnected.
+func (mp *MessageProcessor) infer() {
+	previous := mp.Inference.State
+	moves := mp.Inference.Update(mp.sensors, true)
+	for _, move := range moves {
+		log.Println("Inferred move: " + move.San(mp.Board))
+		mp.applyMove(move, mp.Board.MakeMove(move), false)
+	}
+
+	if mp.Inference.State == InferenceConfirmed {
+		// Whatever was in the air has been put back (or was part
+		// of the moves we just inferred).
+		mp.clearAirState()
+		if len(moves) == 0 && mp.Inference.Position.Piece != mp.Board.Piece {
+			mp.resumeGame(mp.Inference.Position)
+		}
+		mp.Board = mp.Inference.Position
+	}
+
+	mp.reportState(previous)
+}
+
+// reportState reports the inference engine's state if it has changed.
+// While the position has diverged, it's also reported every time the
+// pieces that need putting right change, so that the players can be
+// guided back one square at a time.
+func (mp *MessageProcessor) reportState(previous InferenceState) {
+	var diff PositionDiff
+	if mp.Inference.State == InferenceDiverged {
+		diff = mp.Mismatch()
+	}
+	if mp.Inference.State == previous &&
+		(previous != InferenceDiverged || diff.ToString() == mp.reportedDiff) {
+		return
+	}
+	mp.reportedDiff = diff.ToString()
+
+	log.Println("Position " + mp.Inference.State.String())
+	if diff != nil {
+		log.Println("To restore it: " + diff.ToString())
+	}
+	mp.emit(NewStateEvent(&StateEvent{
+		State: mp.Inference.State,
+		Fen:   mp.Board.Fen(),
+		Diff:  diff,
+		Time:  time.Now(),
+	}))
+}
+
+// Mismatch says what needs to be done to the pieces on the board to
+// get them back to the last confirmed position.
+func (mp *MessageProcessor) Mismatch() PositionDiff {
+	if mp.Board == nil {
+		return nil
+	}
+	return DiffPosition(mp.Board.Piece, mp.sensors)
+}
+
 func (mp *MessageProcessor) processPieceLift(fieldUpdate *FieldUpdate) {
 	// It's a lift, so we have to go and look to see
 	// what was actually there prior to the lift.
@@ -173,6 +390,10 @@ func (mp *MessageProcessor) processPieceDrop(fieldUpdate *FieldUpdate) {
 	// Note that this will update whatever we had previously detected
 	// as being dropped onto this square. But that's OK, because it's
 	// true.
+	if mp.processPlaceGesture(fieldUpdate) {
+		return
+	}
+
 	mp.PiecesDropped[fieldUpdate.Square] = fieldUpdate.Piece
 
 	log.Printf("Piece drop: %s\n", fieldUpdate.ToString())
@@ -183,7 +404,6 @@ func (mp *MessageProcessor) processPieceDrop(fieldUpdate *FieldUpdate) {
 	// other pieces being lifted in the meantime is a special signal.
 	mp.processSpecialSignal()
 	mp.simplifyAirState()
-	mp.IsMovePseudoLegal()
 }
 
 func (mp *MessageProcessor) GetSpecialAirState() (chess.Sq, chess.Piece) {
@@ -216,53 +436,12 @@ func (mp *MessageProcessor) processSpecialSignal() {
 		return
 	}
 
-	// King lifted and dropped onto same square: make that side be
-	// the side to play.
-	if piece == chess.WK {
-		mp.Board.SideToMove = chess.White
-		log.Println("Special signal: White to play")
-	} else if piece == chess.BK {
-		mp.Board.SideToMove = chess.Black
-		log.Println("Special signal: Black to play")
-	} else if piece == chess.WR && square == chess.A1 {
-		// Toggle White's queenside castling rights
-		if mp.Board.CastleSq[chess.WhiteOOO] == chess.NoSquare {
-			log.Println("White may castle queenside")
-			mp.Board.CastleSq[chess.WhiteOOO] = chess.A1
-		} else if mp.Board.CastleSq[chess.WhiteOOO] == chess.A1 {
-			log.Println("White may NOT castle queenside")
-			mp.Board.CastleSq[chess.WhiteOOO] = chess.NoSquare
-		}
-	} else if piece == chess.WR && square == chess.H1 {
-		// Toggle White's kingside castling rights
-		if mp.Board.CastleSq[chess.WhiteOO] == chess.NoSquare {
-			log.Println("White may castle kingside")
-			mp.Board.CastleSq[chess.WhiteOO] = chess.H1
-		} else if mp.Board.CastleSq[chess.WhiteOO] == chess.H1 {
-			log.Println("White may NOT castle kingside")
-			mp.Board.CastleSq[chess.WhiteOO] = chess.NoSquare
-		}
-	} else if piece == chess.BR && square == chess.A8 {
-		// Toggle Black's queenside castling rights
-		if mp.Board.CastleSq[chess.BlackOOO] == chess.NoSquare {
-			log.Println("Black may castle queenside")
-			mp.Board.CastleSq[chess.BlackOOO] = chess.A8
-		} else if mp.Board.CastleSq[chess.BlackOOO] == chess.A8 {
-			log.Println("Black may NOT castle queenside")
-			mp.Board.CastleSq[chess.BlackOOO] = chess.NoSquare
-		}
-	} else if piece == chess.BR && square == chess.H8 {
-		// Toggle Black's kingside castling rights
-		if mp.Board.CastleSq[chess.BlackOO] == chess.NoSquare {
-			log.Println("Black may castle kingside")
-			mp.Board.CastleSq[chess.BlackOO] = chess.H8
-		} else if mp.Board.CastleSq[chess.BlackOO] == chess.H8 {
-			log.Println("Black may NOT castle kingside")
-			mp.Board.CastleSq[chess.BlackOO] = chess.NoSquare
-		}
-	} else {
+	gesture := mp.Gestures.Match(GestureReplace, piece, square)
+	if gesture == nil {
 		log.Println("Couldn't decode special signal: " + fmtpsq(piece, square))
+		return
 	}
+	mp.performGesture(gesture, piece, square)
 }
 
 func (mp *MessageProcessor) simplifyAirState() {
@@ -314,275 +493,54 @@ func (mp *MessageProcessor) processUnrelatedPieceDrop(piece chess.Piece, square
 }
 
 func (mp *MessageProcessor) processTimeUpdate(m *Message) {
-	log.Println("TODO: Implement processTimeUpdate()")
+	mp.Clock = m.TimeUpdate
+	log.Println("Clock: " + mp.Clock.ToString())
 }
 
-func (mp *MessageProcessor) processInfoUpdate(m *Message) {
-	log.Println("TODO: Implement processInfoUpdate()")
+func (mp *MessageProcessor) processClockButtonPress(m *Message) {
+	log.Println(m.ClockButtonPress.ToString())
 }
 
-func (mp *MessageProcessor) IsMovePseudoLegal() bool {
-	totalEnemyPiecesLifted := 0
-	totalOwnPiecesLifted := 0
-	totalEnemyPiecesDropped := 0
-	totalOwnPiecesDropped := 0
-	for _, piece := range mp.PiecesInTheAir {
-		if piece.Color() == mp.Board.SideToMove {
-			totalOwnPiecesLifted++
-		} else {
-			totalEnemyPiecesLifted++
-		}
-	}
-
-	for _, piece := range mp.PiecesDropped {
-		if piece.Color() == mp.Board.SideToMove {
-			totalOwnPiecesDropped++
-		} else {
-			totalEnemyPiecesDropped++
-		}
-	}
-
-	// For a move to be "even pseudolegal":
-
-	// 1. Exactly one (moving, capturing) or two (castling) own pieces
-	// must have been lifted, and the same number dropped.
-	if totalOwnPiecesLifted == 0 {
-		log.Printf("Not a move: no pieces lifted.")
-		return false
-	}
-
-	if totalOwnPiecesLifted > 2 {
-		log.Printf("Not a move: more than two pieces lifted.")
-		return false
-	}
-
-	if totalOwnPiecesLifted > totalOwnPiecesDropped {
-		log.Printf("Not a move: some pieces are still in the air")
-		return false
-	}
-
-	if totalOwnPiecesLifted < totalOwnPiecesDropped {
-		log.Printf("Not a move: some pieces have appeared from thin air")
-		return false
-	}
-
-	// 2. Zero enemy pieces must have been dropped.
-
-	if totalEnemyPiecesDropped > 0 {
-		log.Printf("Not a move: enemy piece dropped.")
-		return false
-	}
-
-	// 3. Exactly ZERO or ONE enemy pieces may have been lifted (captured).
-
-	if totalEnemyPiecesLifted > 1 {
-		log.Printf("Not a move: multiple enemy pieces lifted.")
-		return false
-	}
-
-	// 4. In the case of a castling move, only kings and rooks are
-	// allowed to have been moved. Note that we don't bother checking
-	// for the legality of the actual castling move; we let the chess
-	// library do that. We just check to see if it *looks* like a
-	// castling move.
+func (mp *MessageProcessor) processBatteryStatus(m *Message) {
+	log.Println(m.BatteryStatus.ToString())
+}
 
-	if totalOwnPiecesDropped == 2 {
-		// Looks like a castling move
-		if mp.Board.SideToMove == chess.White {
-			// White to move: The king must have been lifted from E8
-			piece, ok := mp.PiecesInTheAir[chess.E1]
-			if !ok {
-				log.Printf("Not a castling move: nothing moved from e1.")
-				return false
-			}
-			if piece != chess.WK {
-				log.Printf("Not a castling move: piece lifted from e1 != WK")
-				return false
-			}
-			g1drop, kingside := mp.PiecesDropped[chess.G1]
-			c1drop, queenside := mp.PiecesDropped[chess.C1]
-			if kingside {
-				if g1drop != chess.WK {
-					log.Printf("Not a castling move: piece dropped on g1 != WK")
-					return false
-				}
-				h1lift, ok := mp.PiecesInTheAir[chess.H1]
-				if !ok {
-					log.Printf("Not a castling move: no piece taken from h1")
-					return false
-				}
-				if h1lift != chess.WR {
-					log.Printf("Not a castling move: piece taken from h1 != WR")
-					return false
-				}
-				f1drop, ok := mp.PiecesDropped[chess.F1]
-				if !ok {
-					log.Printf("Not a castling move: no piece dropped on f1")
-					return false
-				}
-				if f1drop != chess.WR {
-					log.Printf("Not a castling move: piece dropped on f1 != WR")
-					return false
-				}
-				log.Println("Detecting White O-O")
-				return true
-			} else if queenside {
-				if c1drop != chess.WK {
-					log.Printf("Not a castling move: piece dropped on c1 != WK")
-					return false
-				}
-				a1lift, ok := mp.PiecesInTheAir[chess.A1]
-				if !ok {
-					log.Printf("Not a castling move: no piece taken from a1")
-					return false
-				}
-				if a1lift != chess.WR {
-					log.Printf("Not a castling move: piece taken from a1 != WR")
-					return false
-				}
-				d1drop, ok := mp.PiecesDropped[chess.D1]
-				if !ok {
-					log.Printf("Not a castling move: no piece dropped on d1")
-					return false
-				}
-				if d1drop != chess.WR {
-					log.Printf("Not a castling move: piece dropped on d1 != WR")
-					return false
-				}
-				log.Println("Detecting White O-O-O")
-				return true
-			}
-		} else {
-			// Black to move: The king must have been lifted from E8
-			piece, ok := mp.PiecesInTheAir[chess.E8]
-			if !ok {
-				log.Printf("Not a castling move: nothing moved from e8.")
-				return false
-			}
-			if piece != chess.BK {
-				log.Printf("Not a castling move: piece lifted from e8 != WK")
-				return false
+// processEEMoves replays the board's stored history as though it had
+// been received live. The history doesn't say when the pieces settled,
+// so a piece put down and followed by a piece of the other colour
+// being picked up is taken as the end of a move, as is the end of the
+// history, and the sensors are treated as a stable position there.
+func (mp *MessageProcessor) processEEMoves(m *Message) {
+	var dropped *FieldUpdate
+	for _, message := range m.EEMoves.Messages() {
+		fieldUpdate := message.FieldUpdate
+		if fieldUpdate != nil {
+			if fieldUpdate.Piece == chess.NoPiece && dropped != nil &&
+				mp.sensors[fieldUpdate.Square].Color() != dropped.Piece.Color() {
+				mp.settle()
 			}
-			g8drop, kingside := mp.PiecesDropped[chess.G8]
-			c8drop, queenside := mp.PiecesDropped[chess.C8]
-			if kingside {
-				if g8drop != chess.BK {
-					log.Printf("Not a castling move: piece dropped on g8 != BK")
-					return false
-				}
-				h8lift, ok := mp.PiecesInTheAir[chess.H8]
-				if !ok {
-					log.Printf("Not a castling move: no piece taken from h8")
-					return false
-				}
-				if h8lift != chess.BR {
-					log.Printf("Not a castling move: piece taken from h8 != BR")
-					return false
-				}
-				f8drop, ok := mp.PiecesDropped[chess.F8]
-				if !ok {
-					log.Printf("Not a castling move: no piece dropped on f8")
-					return false
-				}
-				if f8drop != chess.BR {
-					log.Printf("Not a castling move: piece dropped on f8 != BR")
-					return false
-				}
-				log.Println("Detecting Black O-O")
-				return true
-			} else if queenside {
-				if c8drop != chess.BK {
-					log.Printf("Not a castling move: piece dropped on c8 != BK")
-					return false
-				}
-				a8lift, ok := mp.PiecesInTheAir[chess.A8]
-				if !ok {
-					log.Printf("Not a castling move: no piece taken from a8")
-					return false
-				}
-				if a8lift != chess.BR {
-					log.Printf("Not a castling move: piece taken from a8 != BR")
-					return false
-				}
-				d8drop, ok := mp.PiecesDropped[chess.D8]
-				if !ok {
-					log.Printf("Not a castling move: no piece dropped on d8")
-					return false
-				}
-				if d8drop != chess.BR {
-					log.Printf("Not a castling move: piece dropped on d8 != BR")
-					return false
-				}
-				log.Println("Detecting Black O-O-O")
-				return true
+			dropped = nil
+			if fieldUpdate.Piece != chess.NoPiece {
+				dropped = fieldUpdate
 			}
 		}
+		mp.ProcessMessage(message)
 	}
-
-	// 2. If we're not castling, exactly ONE piece needs to have been
-	// moved, which means ONE lift square and ONE drop square, and the
-	// piece needs to be the same piece.
-
-	if totalOwnPiecesDropped != 1 {
-		log.Println("Reached unreachable code")
-		return false
-	}
-
-	var sourceSquare chess.Sq
-	var sourcePiece chess.Piece
-	var targetSquare chess.Sq
-	var targetPiece chess.Piece
-
-	for square, piece := range mp.PiecesInTheAir {
-		if piece.Color() != mp.Board.SideToMove {
-			continue
-		}
-		sourceSquare = square
-		sourcePiece = piece
+	if dropped != nil {
+		mp.settle()
 	}
+}
 
-	for square, piece := range mp.PiecesDropped {
-		if piece.Color() != mp.Board.SideToMove {
-			continue
-		}
-		targetSquare = square
-		targetPiece = piece
-	}
-
-	if sourcePiece != targetPiece {
-		log.Println("Piece changed in midair")
-		return false
-	}
-
-	log.Printf("Detected move: %s -> %s\n", fmtpsq(sourcePiece,
-		sourceSquare), fmtpsq(targetPiece, targetSquare))
-
-	uciMove := fmt.Sprintf("%s%s", sourceSquare, targetSquare)
-	log.Printf("Submitting move [%s]\n", uciMove)
-	parsedMove, err := mp.Board.ParseMove(uciMove)
-	if err != nil {
-		log.Println(err)
-	} else {
-		log.Println("Move accepted!")
-		log.Println(parsedMove.San(mp.Board))
-
-		log.Println("Clearing down field updates ...")
-		mp.PiecesInTheAir = make(map[chess.Sq]chess.Piece)
-		mp.PiecesDropped = make(map[chess.Sq]chess.Piece)
-
-		log.Println("Switching player ...")
-		if mp.Board.SideToMove == chess.White {
-			mp.Board.SideToMove = chess.Black
-			log.Println("Black to move.")
-		} else {
-			mp.Board.SideToMove = chess.White
-			log.Println("White to move.")
-		}
+// settle treats what the sensors currently show as a stable position.
+func (mp *MessageProcessor) settle() {
+	if mp.Board == nil {
+		return
 	}
-
-	return true
+	board := *mp.Board
+	board.Piece = mp.sensors
+	mp.processStableBoard(&board)
 }
 
-func (mp *MessageProcessor) processMove(piece chess.Piece, square chess.Sq) {
-	log.Println("Detected move")
+func (mp *MessageProcessor) processInfoUpdate(m *Message) {
+	log.Println(m.InfoUpdate.ToString())
 }
@@ -0,0 +1,32 @@
+package godgt
+
+import (
+	"testing"
+
+	"github.com/malbrecht/chess"
+)
+
+// Messages that mean nothing to a chess game are ignored, whether or
+// not a game is in progress.
+func TestProcessMessageIgnoresOtherMessages(t *testing.T) {
+	board, err := chess.ParseFen(StartingFen)
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages := []*Message{
+		NewDraughtsFieldUpdateMessage(NewDraughtsFieldUpdate(1, 0, DraughtsWhiteMan)),
+		{},
+	}
+
+	mp := NewMessageProcessor()
+	for _, message := range messages {
+		mp.ProcessMessage(message)
+	}
+	mp.ProcessMessage(NewBoardUpdateMessage(NewBoardUpdate(board)))
+	for _, message := range messages {
+		mp.ProcessMessage(message)
+	}
+	if mp.Board.Fen() != StartingFen {
+		t.Fatalf("position changed to %s", mp.Board.Fen())
+	}
+}
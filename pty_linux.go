@@ -0,0 +1,110 @@
+//go:build linux
+// +build linux
+
+package godgt
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// Pty is a pseudo-terminal pair. The master end is used by whoever is
+// pretending to be the hardware (e.g. a Simulator); the slave end,
+// named by SlaveName, can be opened as a serial port by any program
+// that would otherwise talk to a real board.
+type Pty struct {
+	Master    *os.File
+	SlaveName string
+
+	// We keep our own handle on the slave end open, for two reasons:
+	// so that reads from the master don't fail with EIO while nobody
+	// else has the slave open, and so that the line discipline can
+	// be put into raw mode before anyone starts writing.
+	slave *os.File
+}
+
+// OpenPty allocates a new pseudo-terminal pair in raw mode.
+func OpenPty() (*Pty, error) {
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var ptyNumber uint32
+	err = ioctl(master.Fd(), syscall.TIOCGPTN, uintptr(unsafe.Pointer(&ptyNumber)))
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	var unlock int32
+	err = ioctl(master.Fd(), syscall.TIOCSPTLCK, uintptr(unsafe.Pointer(&unlock)))
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	slaveName := fmt.Sprintf("/dev/pts/%d", ptyNumber)
+	slave, err := os.OpenFile(slaveName, os.O_RDWR|syscall.O_NOCTTY, 0)
+	if err != nil {
+		master.Close()
+		return nil, err
+	}
+
+	err = makeRaw(slave.Fd())
+	if err != nil {
+		slave.Close()
+		master.Close()
+		return nil, err
+	}
+
+	return &Pty{
+		Master:    master,
+		SlaveName: slaveName,
+		slave:     slave,
+	}, nil
+}
+
+func (pty *Pty) Read(p []byte) (int, error) {
+	return pty.Master.Read(p)
+}
+
+func (pty *Pty) Write(p []byte) (int, error) {
+	return pty.Master.Write(p)
+}
+
+func (pty *Pty) Close() error {
+	pty.slave.Close()
+	return pty.Master.Close()
+}
+
+// makeRaw turns off all input and output processing on a terminal,
+// much like cfmakeraw(3).
+func makeRaw(fd uintptr) error {
+	var termios syscall.Termios
+	err := ioctl(fd, syscall.TCGETS, uintptr(unsafe.Pointer(&termios)))
+	if err != nil {
+		return err
+	}
+	termios.Iflag &^= syscall.IGNBRK | syscall.BRKINT | syscall.PARMRK |
+		syscall.ISTRIP | syscall.INLCR | syscall.IGNCR | syscall.ICRNL |
+		syscall.IXON
+	termios.Oflag &^= syscall.OPOST
+	termios.Lflag &^= syscall.ECHO | syscall.ECHONL | syscall.ICANON |
+		syscall.ISIG | syscall.IEXTEN
+	termios.Cflag &^= syscall.CSIZE | syscall.PARENB
+	termios.Cflag |= syscall.CS8
+	termios.Cc[syscall.VMIN] = 1
+	termios.Cc[syscall.VTIME] = 0
+	return ioctl(fd, syscall.TCSETS, uintptr(unsafe.Pointer(&termios)))
+}
+
+func ioctl(fd uintptr, request uintptr, arg uintptr) error {
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, fd, request, arg)
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+//go:build !linux
+// +build !linux
+
+package godgt
+
+import (
+	"errors"
+	"os"
+)
+
+var ERR_PTY_UNSUPPORTED = errors.New("Pseudo-terminals are only supported on Linux")
+
+// Pty is a pseudo-terminal pair; see pty_linux.go. Elsewhere, OpenPty
+// always fails, but the simulator can still be reached over TCP.
+type Pty struct {
+	Master    *os.File
+	SlaveName string
+}
+
+// OpenPty returns ERR_PTY_UNSUPPORTED.
+func OpenPty() (*Pty, error) {
+	return nil, ERR_PTY_UNSUPPORTED
+}
+
+func (pty *Pty) Read(p []byte) (int, error) {
+	return pty.Master.Read(p)
+}
+
+func (pty *Pty) Write(p []byte) (int, error) {
+	return pty.Master.Write(p)
+}
+
+func (pty *Pty) Close() error {
+	return pty.Master.Close()
+}
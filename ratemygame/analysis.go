@@ -1,13 +1,15 @@
 package main
 
-import "encoding/json"
+import (
+	"encoding/json"
+
+	"github.com/kgigitdev/godgt"
+)
 
 // ScoredMove is a single move, and its score, according to the engine.
-type ScoredMove struct {
-	Rank  int     `json:"rank,omitempty"`
-	Move  string  `json:"move"`
-	Score float64 `json:"score"`
-}
+// It's shared with dgtd's live analysis, so that both produce the same
+// JSON.
+type ScoredMove = godgt.ScoredMove
 
 // MoveAnalysis is the analysis of a single move
 type MoveAnalysis struct {
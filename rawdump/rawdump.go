@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -8,15 +9,24 @@ import (
 
 	"github.com/jessevdk/go-flags"
 	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
 )
 
 var opts struct {
 	Pngs bool   `long:"pngs" description:"Write PNG images of board updates"`
-	Port string `short:"p" long:"port" description:"Serial port" default:"/dev/ttyUSB0" env:"DGT_PORT"`
+	Port string `short:"p" long:"port" description:"Serial port, tcp:host:port or file:recording" default:"/dev/ttyUSB0" env:"DGT_PORT"`
 
 	Size int `short:"s" long:"size" description:"Image size" default:"128"`
 
 	Filename string `short:"f" long:"filename" description:"File prefix for png image files" default:"boardupdate"`
+
+	Quiet time.Duration `short:"q" long:"quiet" description:"How long the board must be left alone before its position is taken as stable" default:"500ms"`
+
+	Bus bool `long:"bus" description:"Dump every board chained together on the port, in bus mode"`
+
+	Poll time.Duration `long:"poll" description:"How often to ask the boards on the bus for their changes" default:"250ms"`
+
+	Draughts bool `long:"draughts" description:"The board is a 10x10 draughts board"`
 }
 
 func main() {
@@ -27,34 +37,62 @@ func main() {
 		os.Exit(1)
 	}
 
-	dgtboard := godgt.NewDgtBoard(opts.Port)
+	if opts.Bus {
+		dumpBus()
+		return
+	}
+	if opts.Draughts {
+		dumpDraughts()
+		return
+	}
+
+	dgtboard, err := godgt.NewDgtBoard(opts.Port)
+	if err != nil {
+		log.Fatal(err)
+	}
 
 	dgtboard.WriteCommand(godgt.DGT_SEND_RESET)
 	dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
 	dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_BRD)
 
-	// Ask the board for a complete dump every 10 seconds.
+	// Report how healthy the connection is every 10 seconds.
 	go func() {
-		t := time.NewTicker(time.Second * 10)
-		for {
-			dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
-			<-t.C
+		for range time.Tick(time.Second * 10) {
+			stats := dgtboard.FrameStats()
+			log.Printf("Frames: %d, dropped bytes: %d, resyncs: %d\n",
+				stats.Frames, stats.DroppedBytes, stats.Resyncs)
 		}
 	}()
 
+	// Rather than asking for a dump after every field update, let
+	// the detector ask for one once the pieces have stopped moving.
+	detector := godgt.NewStablePositionDetector(dgtboard, opts.Quiet)
+
 	go dgtboard.ReadLoop()
+	go detector.Run()
 
 	var messageCount int
 
 	for {
 		select {
-		case message := <-dgtboard.MessagesFromBoard:
+		case message, ok := <-detector.Messages:
+			if !ok {
+				// The board has gone away (or the recording
+				// has come to an end).
+				return
+			}
 			messageCount++
 			writeMessage(message)
-			if opts.Pngs && message.BoardUpdate != nil {
+			var board *chess.Board
+			if message.BoardUpdate != nil {
+				board = message.BoardUpdate.Board
+			} else if message.StablePosition != nil {
+				board = message.StablePosition.Board
+			}
+			if opts.Pngs && board != nil {
 				filename := fmt.Sprintf("%s-%04d.png",
 					opts.Filename, messageCount)
-				fen := message.BoardUpdate.ToString()
+				fen := board.Fen()
 				godgt.WritePng(fen, opts.Size, filename)
 				// Hack: always make a copy of the
 				// latest image to known, constant
@@ -66,13 +104,99 @@ func main() {
 				godgt.CopyFile(filename, latest)
 
 			}
-			if message.FieldUpdate != nil {
-				dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
+		}
+	}
+}
+
+// dumpDraughts dumps the messages from a draughts board. There's no
+// StablePositionDetector for draughts, so a complete dump is asked for
+// after every field update instead.
+func dumpDraughts() {
+	dgtboard, err := godgt.NewDgtDraughtsBoard(opts.Port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	dgtboard.WriteCommand(godgt.DGT_SEND_RESET)
+	dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
+	dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_BRD)
+
+	go dgtboard.ReadLoop()
+
+	var messageCount int
+	for message := range dgtboard.MessagesFromBoard {
+		messageCount++
+		if message.DraughtsBoardUpdate != nil {
+			position := message.DraughtsBoardUpdate.Position
+			log.Print("BOARD: ", position.Fen())
+			for _, row := range position.Rows() {
+				log.Print(row)
 			}
+			if opts.Pngs {
+				filename := fmt.Sprintf("%s-%04d.png",
+					opts.Filename, messageCount)
+				godgt.WriteDraughtsPng(position, opts.Size, filename)
+				latest := fmt.Sprintf("%s-latest.png",
+					opts.Filename)
+				godgt.CopyFile(filename, latest)
+			}
+		} else if message.DraughtsFieldUpdate != nil {
+			log.Print("FIELD: ", message.ToString())
+			dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
+		} else {
+			log.Print("OTHER: ", message.ToString())
 		}
 	}
 }
 
+// dumpBus finds the boards on a bus, and dumps the messages from all
+// of them, each preceded by the board's bus address.
+func dumpBus() {
+	bus, err := godgt.NewDgtBus(opts.Port)
+	if err != nil {
+		log.Fatal(err)
+	}
+	go bus.ReadLoop()
+
+	err = bus.ToBusMode()
+	if err != nil {
+		log.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	addresses, err := bus.Discover(ctx)
+	cancel()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(addresses) == 0 {
+		log.Fatal("No boards found on the bus.")
+	}
+
+	type busMessage struct {
+		address int
+		message *godgt.Message
+	}
+	messages := make(chan busMessage)
+	for _, address := range addresses {
+		dgtboard := bus.Board(address)
+		dgtboard.WriteCommand(godgt.DGT_SEND_BRD)
+		dgtboard.WriteCommand(godgt.DGT_SEND_UPDATE_BRD)
+		detector := godgt.NewStablePositionDetector(dgtboard, opts.Quiet)
+		go detector.Run()
+		go func(address int) {
+			for message := range detector.Messages {
+				messages <- busMessage{address, message}
+			}
+		}(address)
+	}
+	go bus.Poll(context.Background(), opts.Poll)
+
+	for m := range messages {
+		log.Printf("Board %d:\n", m.address)
+		writeMessage(m.message)
+	}
+}
+
 func writeMessage(m *godgt.Message) {
 	if m.BoardUpdate != nil {
 		log.Print("BOARD: ", m.ToString())
@@ -80,6 +204,12 @@ func writeMessage(m *godgt.Message) {
 		for _, row := range rows {
 			log.Print(row)
 		}
+	} else if m.StablePosition != nil {
+		log.Print("STABLE: ", m.StablePosition.Board.Fen())
+		rows := godgt.SimpleBoardFromFen(m.StablePosition.Board.Fen())
+		for _, row := range rows {
+			log.Print(row)
+		}
 	} else if m.FieldUpdate != nil {
 		log.Print("FIELD: ", m.ToString())
 	} else {
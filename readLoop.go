@@ -1,36 +1,93 @@
 package godgt
 
-import "log"
+import (
+	"context"
+	"errors"
+	"log"
+)
 
-func (dgtboard *DgtBoard) ReadLoop() {
-	for {
-		// log.Println("About to read bytes")
-		dgtboard.readBytes()
-		// log.Println("About to parse bytes")
-		message, err := dgtboard.parseBytes()
-
-		// FIXME: here we are discarding ALL errors,
-		// but printing them all out is noisy; lots of
-		// the errors are benign (e.g. "not enough bytes in buffer").
-		// Maybe return a nil error in those cases and also a nil
-		// message?
-		if err == nil {
-			dgtboard.MessagesFromBoard <- message
-		}
+var ERR_ALREADY_RUNNING = errors.New("Already reading from the board")
+
+// Run reads messages from the board and sends them to
+// MessagesFromBoard, until the context is done, Stop is called, or
+// reading from the board fails. Either way, the port is closed, and so
+// is MessagesFromBoard, so that whoever is reading the messages knows
+// that there won't be any more.
+//
+// Run returns the error that ended it (io.EOF, for example, when the
+// board is unplugged, or at the end of a recording), or nil if it was
+// stopped. The error is also kept for Err. A DgtBoard can only be run
+// once.
+func (dgtboard *DgtBoard) Run(ctx context.Context) error {
+	dgtboard.runMutex.Lock()
+	if dgtboard.done != nil {
+		dgtboard.runMutex.Unlock()
+		return ERR_ALREADY_RUNNING
 	}
+	ctx, cancel := context.WithCancel(ctx)
+	dgtboard.cancel = cancel
+	dgtboard.done = make(chan struct{})
+	dgtboard.runMutex.Unlock()
+
+	// A read can't be cancelled, but closing the port wakes it up.
+	go func() {
+		<-ctx.Done()
+		dgtboard.port.Close()
+	}()
+
+	err := dgtboard.readLoop(ctx)
+	if ctx.Err() != nil {
+		// Whatever went wrong was caused by stopping.
+		err = nil
+	}
+	cancel()
+
+	// Keep the error before closing the channel, so that it's there
+	// for anyone who notices the channel closing.
+	dgtboard.runMutex.Lock()
+	dgtboard.err = err
+	dgtboard.runMutex.Unlock()
+	close(dgtboard.MessagesFromBoard)
+	close(dgtboard.done)
+	return err
 }
 
-func (dgtboard *DgtBoard) readBytes() {
-	buf := make([]byte, 1024)
-	n, err := dgtboard.port.Read(buf)
+// ReadLoop is Run, without a context, for programs that don't need to
+// stop it. The error that ended it is logged.
+func (dgtboard *DgtBoard) ReadLoop() {
+	err := dgtboard.Run(context.Background())
 	if err != nil {
-		log.Println("error reading bytes.")
+		log.Println("Stopped reading from the board: " + err.Error())
 	}
-	if n > 0 {
-		// fmt.Printf("Read %d bytes\n", n)
-		for i := 0; i < n; i++ {
-			b := buf[i]
-			dgtboard.bytesFromBoard = append(dgtboard.bytesFromBoard, b)
+}
+
+func (dgtboard *DgtBoard) readLoop(ctx context.Context) error {
+	buf := make([]byte, 1024)
+	for {
+		n, err := dgtboard.port.Read(buf)
+		if n > 0 {
+			dgtboard.decoder.Write(buf[:n])
+		}
+		for {
+			message, err := dgtboard.parseBytes()
+			if err != nil {
+				log.Println(err)
+				continue
+			}
+			if message == nil {
+				// Wait for more bytes.
+				break
+			}
+			select {
+			case dgtboard.MessagesFromBoard <- message:
+			case <-ctx.Done():
+				return nil
+			}
+		}
+		// Anything read along with the error has been passed on,
+		// but nothing more is coming.
+		if err != nil {
+			return err
 		}
 	}
 }
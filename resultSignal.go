@@ -0,0 +1,40 @@
+package godgt
+
+import "github.com/malbrecht/chess"
+
+// ResultSignal reports one of the magic result pieces (PIECE1, PIECE2
+// or PIECE3) being put on the board, which is how players tell the
+// board that a game is over.
+type ResultSignal struct {
+	// One of ResultDraw, ResultWhiteWins or ResultBlackWins.
+	Result string
+
+	// Where the magic piece was put.
+	Square chess.Sq
+}
+
+func NewResultSignal(result string, square chess.Sq) *ResultSignal {
+	return &ResultSignal{
+		Result: result,
+		Square: square,
+	}
+}
+
+func (rs *ResultSignal) ToString() string {
+	return "Result: " + rs.Result
+}
+
+// getResultByGdtPieceCode returns the result that a magic piece
+// stands for, or "" if the piece code isn't a magic piece.
+func getResultByGdtPieceCode(gdtPieceCode byte) string {
+	switch gdtPieceCode {
+	case PIECE1:
+		return ResultDraw
+	case PIECE2:
+		return ResultWhiteWins
+	case PIECE3:
+		return ResultBlackWins
+	default:
+		return ""
+	}
+}
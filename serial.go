@@ -6,6 +6,9 @@ import (
 	"github.com/jacobsa/go-serial/serial"
 )
 
+// CreatePort opens a serial port at 9600 8N1, which is what all the
+// DGT boards speak. Most callers will want NewSerialTransport or
+// OpenTransport instead.
 func CreatePort(portName string) (io.ReadWriteCloser, error) {
 	options := serial.OpenOptions{
 		PortName:        portName,
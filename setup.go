@@ -0,0 +1,183 @@
+package godgt
+
+import (
+	"log"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// How a new game gets started. When the MessageProcessor sees a
+// position with no game in progress (the first board dump, or after a
+// result), or sees the pieces set up for a new game:
+//
+// - The standard starting position starts a normal game.
+//
+// - A Chess960 starting position (pawns on their usual squares, and
+//   the pieces on the back rank shuffled as the Chess960 rules allow)
+//   starts a Chess960 game, with castling between the king and
+//   wherever its rooks are.
+//
+// - Anything else is taken as a setup which the players need to
+//   confirm, since the board can't know whose move it is, or who may
+//   still castle. Lifting and replacing a corner rook toggles that
+//   side's castling rights, and lifting and replacing a king makes it
+//   that side's move and starts the game.
+
+// startPosition works out what kind of game the position on the board
+// is the start of.
+func (mp *MessageProcessor) startPosition(board *chess.Board) {
+	mp.sensors = board.Piece
+	mp.clearAirState()
+
+	if isStandardStart(board.Piece) {
+		start, err := chess.ParseFen(StartingFen)
+		if err == nil {
+			mp.startGame(start, false)
+			return
+		}
+	}
+
+	if isChess960Start(board.Piece) {
+		mp.startGame(chess960Board(board.Piece), true)
+		return
+	}
+
+	setup := *board
+	mp.Board = &setup
+	mp.Chess960 = false
+	mp.AwaitingSetup = true
+	log.Println("Unrecognised setup: " + mp.Board.Fen())
+	log.Println("Lift and replace the king of the side to move to start.")
+	mp.emit(NewSetupEvent(&SetupEvent{
+		Fen:  mp.Board.Fen(),
+		Time: time.Now(),
+	}))
+}
+
+// startGame starts a new game from a position.
+func (mp *MessageProcessor) startGame(board *chess.Board, chess960 bool) {
+	mp.Board = board
+	mp.Chess960 = chess960
+	mp.AwaitingSetup = false
+	mp.Inference = NewInferenceEngine(board)
+	mp.history = nil
+	mp.awaitingTakeback = false
+	mp.clearAirState()
+	log.Println("New game: " + board.Fen())
+	mp.emit(NewResetEvent(&ResetEvent{
+		Fen:      board.Fen(),
+		Chess960: chess960,
+		Time:     time.Now(),
+	}))
+}
+
+// resumeGame carries on with the game in progress from a position that
+// was reached by moves that can't be reported, because more than one
+// order of them leads there. The moves before it can no longer be
+// taken back.
+func (mp *MessageProcessor) resumeGame(board *chess.Board) {
+	mp.Board = board
+	mp.history = nil
+	log.Println("Carrying on from: " + board.Fen())
+	mp.emit(NewResetEvent(&ResetEvent{
+		Fen:      board.Fen(),
+		Chess960: mp.Chess960,
+		Resumed:  true,
+		Time:     time.Now(),
+	}))
+}
+
+var standardStartPieces [64]chess.Piece
+
+func init() {
+	board, err := chess.ParseFen(StartingFen)
+	if err != nil {
+		panic(err)
+	}
+	standardStartPieces = board.Piece
+}
+
+func isStandardStart(pieces [64]chess.Piece) bool {
+	return pieces == standardStartPieces
+}
+
+// blackCounterpart maps each white piece to its black equivalent.
+var blackCounterpart = map[chess.Piece]chess.Piece{
+	chess.WR: chess.BR,
+	chess.WN: chess.BN,
+	chess.WB: chess.BB,
+	chess.WQ: chess.BQ,
+	chess.WK: chess.BK,
+}
+
+// isChess960Start is true if the pieces are in one of the 960
+// starting positions of Chess960 (which include the standard one).
+func isChess960Start(pieces [64]chess.Piece) bool {
+	counts := make(map[chess.Piece]int)
+	var bishopFiles, rookFiles []int
+	kingFile := -1
+
+	for file := 0; file < 8; file++ {
+		white := pieces[chess.Square(file, 0)]
+		black, ok := blackCounterpart[white]
+		if !ok || pieces[chess.Square(file, 7)] != black {
+			return false
+		}
+		if pieces[chess.Square(file, 1)] != chess.WP ||
+			pieces[chess.Square(file, 6)] != chess.BP {
+			return false
+		}
+		for rank := 2; rank < 6; rank++ {
+			if pieces[chess.Square(file, rank)] != chess.NoPiece {
+				return false
+			}
+		}
+
+		counts[white]++
+		switch white {
+		case chess.WB:
+			bishopFiles = append(bishopFiles, file)
+		case chess.WR:
+			rookFiles = append(rookFiles, file)
+		case chess.WK:
+			kingFile = file
+		}
+	}
+
+	if counts[chess.WR] != 2 || counts[chess.WN] != 2 || counts[chess.WB] != 2 ||
+		counts[chess.WQ] != 1 || counts[chess.WK] != 1 {
+		return false
+	}
+
+	// The bishops must be on squares of opposite colours, and the
+	// king must be between the rooks.
+	if (bishopFiles[0]+bishopFiles[1])%2 == 0 {
+		return false
+	}
+	return rookFiles[0] < kingFile && kingFile < rookFiles[1]
+}
+
+// chess960Board returns a Chess960 starting position, with White to
+// move and full castling rights. The pieces must already have passed
+// isChess960Start.
+func chess960Board(pieces [64]chess.Piece) *chess.Board {
+	var rookFiles []int
+	for file := 0; file < 8; file++ {
+		if pieces[chess.Square(file, 0)] == chess.WR {
+			rookFiles = append(rookFiles, file)
+		}
+	}
+
+	board := &chess.Board{
+		Piece:      pieces,
+		SideToMove: chess.White,
+		EpSquare:   chess.NoSquare,
+		MoveNr:     1,
+	}
+	board.CastleSq[chess.WhiteOOO] = chess.Square(rookFiles[0], 0)
+	board.CastleSq[chess.WhiteOO] = chess.Square(rookFiles[1], 0)
+	board.CastleSq[chess.BlackOOO] = chess.Square(rookFiles[0], 7)
+	board.CastleSq[chess.BlackOO] = chess.Square(rookFiles[1], 7)
+	return board
+}
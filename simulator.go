@@ -0,0 +1,777 @@
+package godgt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+var ERR_SIMULATOR_ALREADY_RUNNING = errors.New("Simulator already running")
+
+// Simulator is a software DGT board. It answers the commands arriving
+// over its Transport the same way that the real hardware does, and
+// turns physical actions (lifting and placing pieces, pressing the
+// clock lever) into the messages that a real board would send. Run it
+// over a Pty, and programs like dgtd and rawdump can't tell the
+// difference; run it over one end of NewPipeTransport, and a DgtBoard
+// on the other end can be driven entirely in memory.
+type Simulator struct {
+	port  Transport
+	mutex sync.Mutex
+
+	// The pieces on the board, as DGT piece codes, indexed by DGT
+	// field number (a8=0, h1=63).
+	pieces [64]byte
+
+	// Pieces that have been lifted and not yet put down again, most
+	// recently lifted last.
+	hand []byte
+
+	// The board mode, which is the last of DGT_SEND_RESET,
+	// DGT_SEND_UPDATE, DGT_SEND_UPDATE_BRD or DGT_SEND_UPDATE_NICE
+	// received.
+	mode byte
+
+	// The move storage, as returned by DGT_SEND_EE_MOVES.
+	storage []byte
+
+	// In bus mode, the board only talks when spoken to, and only
+	// answers bus commands.
+	busMode bool
+	// How much of the storage has been sent in answer to
+	// DGT_BUS_SEND_CHANGES, and what was sent last time, in case it
+	// has to be repeated.
+	busSent    int
+	busChanges []byte
+	// Where the storage was when DGT_BUS_SET_START_GAME was last
+	// received.
+	busGameStart int
+	ignorePing   bool
+
+	clock simulatedClock
+
+	SerialNumber     string
+	LongSerialNumber string
+	VersionMajor     byte
+	VersionMinor     byte
+	Trademark        string
+
+	started bool
+	done    chan struct{}
+}
+
+// simulatedClock is a DGT XL or DGT3000 attached to the simulated
+// board.
+type simulatedClock struct {
+	left       time.Duration
+	right      time.Duration
+	running    bool
+	leftToMove bool
+	connected  bool
+}
+
+// NewSimulator returns a simulated board, with the pieces in the
+// starting position, talking over the given transport.
+func NewSimulator(port Transport) *Simulator {
+	sim := &Simulator{
+		port:             port,
+		mode:             DGT_SEND_RESET,
+		SerialNumber:     "00001",
+		LongSerialNumber: "0000000001",
+		VersionMajor:     1,
+		VersionMinor:     2,
+		Trademark:        "Digital Game Technology\r\nsimulated board",
+		done:             make(chan struct{}),
+	}
+	board, err := chess.ParseFen(StartingFen)
+	if err != nil {
+		panic(err)
+	}
+	sim.SetPosition(board)
+	return sim
+}
+
+// Run answers commands from the transport until it is closed or fails.
+// A Simulator can only be run once.
+func (sim *Simulator) Run() error {
+	sim.mutex.Lock()
+	if sim.started {
+		sim.mutex.Unlock()
+		return ERR_SIMULATOR_ALREADY_RUNNING
+	}
+	sim.started = true
+	sim.mutex.Unlock()
+
+	go sim.runClock()
+	defer close(sim.done)
+
+	reader := bufio.NewReader(sim.port)
+	for {
+		command, err := reader.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch {
+		case command&MESSAGE_BIT != 0:
+			// A bus command: the address and checksum follow.
+			rest := make([]byte, 3)
+			_, err = io.ReadFull(reader, rest)
+			if err != nil {
+				return err
+			}
+			sim.handleBusCommand(command, rest)
+		case command == DGT_CLOCK_MESSAGE || command == DGT_SET_LEDS:
+			// These are the only commands with arguments: a
+			// size byte, followed by that many bytes.
+			size, err := reader.ReadByte()
+			if err != nil {
+				return err
+			}
+			content := make([]byte, size)
+			_, err = io.ReadFull(reader, content)
+			if err != nil {
+				return err
+			}
+			sim.handleLongCommand(command, content)
+		default:
+			sim.handleCommand(command)
+		}
+	}
+}
+
+func (sim *Simulator) handleCommand(command byte) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	if sim.busMode {
+		// Single-board commands mean nothing on the bus.
+		return
+	}
+
+	switch command {
+	case DGT_SEND_RESET, DGT_SEND_UPDATE, DGT_SEND_UPDATE_BRD,
+		DGT_SEND_UPDATE_NICE:
+		sim.mode = command
+	case DGT_TO_BUSMODE:
+		sim.startBusMode()
+	case DGT_SEND_BRD:
+		sim.send(DGT_BOARD_DUMP, sim.pieces[:])
+	case DGT_SEND_CLK:
+		sim.sendTime()
+	case DGT_SEND_VERSION:
+		sim.send(DGT_VERSION, []byte{sim.VersionMajor, sim.VersionMinor})
+	case DGT_SEND_TRADEMARK:
+		sim.send(DGT_TRADEMARK, []byte(sim.Trademark))
+	case DGT_RETURN_SERIALNR:
+		sim.send(DGT_SERIALNR, []byte(sim.SerialNumber))
+	case DGT_SEND_BATTERY_STATUS:
+		// Always fully charged, and with no idea how long that'll
+		// last.
+		sim.send(DGT_BATTERY_STATUS, []byte{100, 0x7f, 0x7f, 0, 0, 0, 0, 0, 0x02})
+	case DGT_RETURN_LONG_SERIALNR:
+		sim.send(DGT_LONG_SERIALNR, []byte(sim.LongSerialNumber))
+	case DGT_RETURN_BUSADRES:
+		address := sim.busAddress()
+		sim.send(DGT_BUSADRES, []byte{byte(address>>7) & 0x7f, byte(address) & 0x7f})
+	case DGT_SEND_EE_MOVES:
+		storage := append([]byte{}, sim.storage...)
+		storage = append(storage, EE_EOF)
+		sim.send(DGT_EE_MOVES, storage)
+		sim.storage = append(sim.storage, EE_DOWNLOADED)
+	default:
+		log.Printf("Simulator: ignoring command 0x%02x\n", command)
+	}
+}
+
+// busAddress is the serial number, in binary.
+func (sim *Simulator) busAddress() int {
+	address, _ := strconv.Atoi(sim.SerialNumber)
+	return address
+}
+
+// startBusMode stops the board sending anything of its own accord.
+func (sim *Simulator) startBusMode() {
+	sim.busMode = true
+	sim.mode = DGT_SEND_RESET
+}
+
+// handleBusCommand answers a bus command, if it's addressed to this
+// board. Like a real board, one that isn't in bus mode yet switches to
+// it, and otherwise ignores the command.
+func (sim *Simulator) handleBusCommand(command byte, rest []byte) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	if busChecksum([]byte{command, rest[0], rest[1]}) != rest[2] {
+		log.Printf("Simulator: bad checksum on bus command 0x%02x\n", command)
+		return
+	}
+	if !sim.busMode {
+		sim.startBusMode()
+		return
+	}
+	address := int(rest[0])<<7 | int(rest[1])
+	if address == 0 && command == DGT_BUS_PING {
+		if sim.ignorePing {
+			sim.ignorePing = false
+			return
+		}
+		// Wait a while, so as not to talk over the other boards.
+		delay := time.Duration(rand.Intn(int(BusDiscoveryWindow/2))) + 1
+		time.AfterFunc(delay, func() {
+			sim.mutex.Lock()
+			defer sim.mutex.Unlock()
+			sim.sendBus(DGT_BUS_PING_REPLY, nil)
+		})
+		return
+	}
+	if address != sim.busAddress() {
+		return
+	}
+
+	switch command {
+	case DGT_BUS_SEND_CLK:
+		sim.sendBus(DGT_BUS_CLK_DATA, sim.timeData())
+	case DGT_BUS_SEND_BRD:
+		sim.sendBus(DGT_BUS_BRD_DUMP, sim.pieces[:])
+	case DGT_BUS_SEND_CHANGES:
+		sim.busChanges = append([]byte{}, sim.storage[sim.busSent:]...)
+		sim.busSent = len(sim.storage)
+		sim.sendBus(DGT_BUS_UPDATE, sim.busChanges)
+	case DGT_BUS_REPEAT_CHANGES:
+		sim.sendBus(DGT_BUS_UPDATE, sim.busChanges)
+	case DGT_BUS_SET_START_GAME:
+		sim.busGameStart = len(sim.storage)
+	case DGT_BUS_SEND_FROM_START:
+		sim.sendBus(DGT_BUS_FROM_START, sim.storage[sim.busGameStart:])
+	case DGT_BUS_PING:
+		sim.sendBus(DGT_BUS_PING_REPLY, nil)
+	case DGT_BUS_END_BUSMODE:
+		sim.busMode = false
+	case DGT_BUS_RESET:
+		sim.storage = append(sim.storage, EE_POWERUP)
+		sim.storage = append(sim.storage, sim.pieces[:]...)
+	case DGT_BUS_IGNORE_NEXT_BUS_PING:
+		sim.ignorePing = true
+	default:
+		log.Printf("Simulator: ignoring bus command 0x%02x\n", command)
+	}
+}
+
+func (sim *Simulator) handleLongCommand(command byte, content []byte) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	if command == DGT_SET_LEDS {
+		// We have no LEDs to light, so just say which would be.
+		if len(content) >= 3 {
+			state := "off"
+			if content[0] != LED_OFF {
+				state = "on"
+			}
+			from := chess.Square(int(content[1]%8), 7-int(content[1]/8))
+			to := chess.Square(int(content[2]%8), 7-int(content[2]/8))
+			log.Printf("Simulator: LEDs %s from %s to %s\n", state, from, to)
+		}
+		return
+	}
+
+	// The content starts with DGT_CMD_CLOCK_START_MESSAGE and ends
+	// with DGT_CMD_CLOCK_END_MESSAGE.
+	if len(content) < 3 {
+		log.Println("Simulator: short clock message")
+		return
+	}
+	clockCommand := content[1]
+	arguments := content[2 : len(content)-1]
+
+	var ack0 byte = 0x10
+	var ack2 byte
+	switch clockCommand {
+	case DGT_CMD_CLOCK_SETNRUN:
+		if len(arguments) < 7 {
+			ack0 = 0x40
+			break
+		}
+		sim.clock.left = clockTime(arguments[0], arguments[1], arguments[2])
+		sim.clock.right = clockTime(arguments[3], arguments[4], arguments[5])
+		flags := arguments[6]
+		sim.clock.running = flags&0x04 == 0 && flags&0x03 != 0
+		sim.clock.leftToMove = flags&0x01 == 0x01
+	case DGT_CMD_CLOCK_VERSION:
+		ack2 = 0x21
+	case DGT_CMD_CLOCK_ASCII:
+		if len(arguments) < 8 {
+			ack0 = 0x40
+			break
+		}
+		log.Printf("Simulator: clock shows %q\n", arguments[:8])
+	case DGT_CMD_CLOCK_END:
+		log.Println("Simulator: clock shows the times")
+	}
+
+	// Acks are only sent in the update modes.
+	if sim.mode == DGT_SEND_UPDATE || sim.mode == DGT_SEND_UPDATE_NICE {
+		sim.sendAck(ack0, clockCommand, ack2, 0)
+	}
+}
+
+func (sim *Simulator) sendTime() {
+	sim.send(DGT_BWTIME, sim.timeData())
+}
+
+// timeData returns the 7 bytes of a DGT_BWTIME message.
+func (sim *Simulator) timeData() []byte {
+	rightHours, rightMinutes, rightSeconds := encodeClockTime(sim.clock.right)
+	leftHours, leftMinutes, leftSeconds := encodeClockTime(sim.clock.left)
+	var status byte
+	if sim.clock.running {
+		status |= 0x01
+	}
+	if sim.clock.leftToMove {
+		status |= 0x10
+	} else {
+		// The player who has just moved pressed their side of
+		// the lever down, so the right side is high.
+		status |= 0x08 | 0x02
+	}
+	if !sim.clock.connected {
+		status |= 0x20
+	}
+	return []byte{
+		rightHours, rightMinutes, rightSeconds,
+		leftHours, leftMinutes, leftSeconds,
+		status,
+	}
+}
+
+// sendAck encodes the four ack bytes into a DGT_BWTIME message, which
+// is the reverse of the recipe given in dgtconstants.go.
+func (sim *Simulator) sendAck(ack0, ack1, ack2, ack3 byte) {
+	sim.send(DGT_BWTIME, []byte{
+		0x0a | (ack2&0x80)>>3 | (ack3&0x80)>>2,
+		ack0 & 0x7f,
+		ack1 & 0x7f,
+		0x0a | (ack0&0x80)>>3 | (ack1&0x80)>>2,
+		ack2 & 0x7f,
+		ack3 & 0x7f,
+		0,
+	})
+}
+
+func (sim *Simulator) send(messageId byte, data []byte) {
+	_, err := sim.port.Write(encodeMessage(messageId, data))
+	if err != nil {
+		log.Println("Simulator: write failed:", err)
+	}
+}
+
+// sendBus sends a bus message: the address, the data and a checksum.
+func (sim *Simulator) sendBus(messageId byte, data []byte) {
+	address := sim.busAddress()
+	data = append([]byte{byte(address>>7) & 0x7f, byte(address) & 0x7f}, data...)
+	// Leave room for the checksum, and then fill it in.
+	message := encodeMessage(messageId, append(data, 0))
+	message[len(message)-1] = busChecksum(message[:len(message)-1])
+	_, err := sim.port.Write(message)
+	if err != nil {
+		log.Println("Simulator: write failed:", err)
+	}
+}
+
+// runClock counts down the clock of the player to move, once a second,
+// and sends the time in UPDATE or UPDATE_NICE mode.
+func (sim *Simulator) runClock() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-sim.done:
+			return
+		case <-ticker.C:
+		}
+		sim.mutex.Lock()
+		if sim.clock.running {
+			if sim.clock.leftToMove {
+				sim.clock.left -= time.Second
+			} else {
+				sim.clock.right -= time.Second
+			}
+			if sim.clock.left <= 0 || sim.clock.right <= 0 {
+				sim.clock.running = false
+			}
+		}
+		if sim.mode == DGT_SEND_UPDATE ||
+			(sim.mode == DGT_SEND_UPDATE_NICE && sim.clock.running) {
+			sim.sendTime()
+		}
+		sim.mutex.Unlock()
+	}
+}
+
+// SetPosition puts pieces on the board as if it had been switched on
+// with them already there. No field updates are sent.
+func (sim *Simulator) SetPosition(board *chess.Board) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	for square := chess.A1; square <= chess.H8; square++ {
+		field := getGdtFieldNumberFromChessSquare(square)
+		sim.pieces[field] = getGdtPieceCodeByChessPiece(board.Piece[square])
+	}
+	sim.hand = nil
+	sim.storage = append(sim.storage, EE_POWERUP)
+	sim.storage = append(sim.storage, sim.pieces[:]...)
+}
+
+// SetClock connects a clock to the board, stopped, showing the given
+// times.
+func (sim *Simulator) SetClock(left time.Duration, right time.Duration) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	sim.clock.connected = true
+	sim.clock.running = false
+	sim.clock.left = left
+	sim.clock.right = right
+	sim.recordTimes()
+}
+
+// StartClock starts the clock, running for the left player if left is
+// true and the right player otherwise.
+func (sim *Simulator) StartClock(left bool) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	sim.clock.running = true
+	sim.clock.leftToMove = left
+}
+
+// StopClock stops the clock.
+func (sim *Simulator) StopClock() {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	sim.clock.running = false
+}
+
+// PressLever flips the clock lever, stopping the clock of the player
+// to move and starting their opponent's.
+func (sim *Simulator) PressLever() {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	sim.clock.leftToMove = !sim.clock.leftToMove
+	sim.recordTimes()
+	if sim.mode == DGT_SEND_UPDATE || sim.mode == DGT_SEND_UPDATE_NICE {
+		sim.sendTime()
+	}
+}
+
+// PressButton presses one of the buttons on the clock. Like the real
+// clock, this is reported with an auto-generated ack, so only in the
+// update modes.
+func (sim *Simulator) PressButton(button ClockButton) {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	if sim.mode == DGT_SEND_UPDATE || sim.mode == DGT_SEND_UPDATE_NICE {
+		sim.sendAck(0x10, CLOCK_ACK_BUTTON, 0, byte(button))
+	}
+}
+
+func (sim *Simulator) recordTimes() {
+	hours, minutes, seconds := encodeClockTime(sim.clock.left)
+	sim.storage = append(sim.storage, EE_LEFT_TIME|hours, minutes, seconds)
+	hours, minutes, seconds = encodeClockTime(sim.clock.right)
+	sim.storage = append(sim.storage, EE_RIGHT_TIME|hours, minutes, seconds)
+}
+
+// Lift picks up the piece on a square.
+func (sim *Simulator) Lift(square chess.Sq) error {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	field := getGdtFieldNumberFromChessSquare(square)
+	if sim.pieces[field] == EMPTY {
+		return fmt.Errorf("Nothing to lift on %s", square)
+	}
+	sim.hand = append(sim.hand, sim.pieces[field])
+	sim.changeField(field, EMPTY)
+	return nil
+}
+
+// Place puts a piece down on a square. If piece is chess.NoPiece, the
+// piece most recently lifted is used. Placing a piece on an occupied
+// square replaces whatever was there, just as the sensors would see
+// it.
+func (sim *Simulator) Place(square chess.Sq, piece chess.Piece) error {
+	sim.mutex.Lock()
+	defer sim.mutex.Unlock()
+
+	var code byte
+	if piece == chess.NoPiece {
+		if len(sim.hand) == 0 {
+			return fmt.Errorf("Nothing in hand to place on %s", square)
+		}
+		code = sim.hand[len(sim.hand)-1]
+		sim.hand = sim.hand[:len(sim.hand)-1]
+	} else {
+		code = getGdtPieceCodeByChessPiece(piece)
+		sim.removeFromHand(code)
+	}
+	sim.changeField(getGdtFieldNumberFromChessSquare(square), code)
+	return nil
+}
+
+// removeFromHand forgets the most recently lifted instance of a piece
+// that is being placed explicitly. It's not an error for the piece not
+// to be in hand; it might, for example, be a promotion piece.
+func (sim *Simulator) removeFromHand(code byte) {
+	for i := len(sim.hand) - 1; i >= 0; i-- {
+		if sim.hand[i] == code {
+			sim.hand = append(sim.hand[:i], sim.hand[i+1:]...)
+			return
+		}
+	}
+}
+
+// Slide moves a piece by dragging it across the board, along a path of
+// squares ending with its destination. Each square along the way sees
+// the piece arrive and then leave again, which produces the same storm
+// of field updates as a real slide.
+func (sim *Simulator) Slide(from chess.Sq, path []chess.Sq) error {
+	if len(path) == 0 {
+		return errors.New("Slide needs at least one square to slide to")
+	}
+	err := sim.Lift(from)
+	if err != nil {
+		return err
+	}
+	for _, square := range path[:len(path)-1] {
+		err = sim.Place(square, chess.NoPiece)
+		if err != nil {
+			return err
+		}
+		err = sim.Lift(square)
+		if err != nil {
+			return err
+		}
+	}
+	return sim.Place(path[len(path)-1], chess.NoPiece)
+}
+
+// changeField updates a field, and tells anyone listening.
+func (sim *Simulator) changeField(field byte, code byte) {
+	sim.pieces[field] = code
+	sim.storage = append(sim.storage, EE_FIELDCHANGE|code, field)
+	if sim.mode != DGT_SEND_RESET {
+		sim.send(DGT_FIELD_UPDATE, []byte{field, code})
+	}
+}
+
+// RunScript performs the actions in a script, one per line or
+// separated by commas, pausing between each. See Do for the actions
+// understood. Blank lines and lines starting with '#' are ignored.
+func (sim *Simulator) RunScript(r io.Reader, pause time.Duration) error {
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		for _, action := range strings.Split(line, ",") {
+			err := sim.Do(action)
+			if err != nil {
+				return fmt.Errorf("line %d: %s", lineNumber, err)
+			}
+			time.Sleep(pause)
+		}
+	}
+	return scanner.Err()
+}
+
+// Do performs a single scripted action. The following are understood:
+//
+//	lift e2                     pick up a piece
+//	place e4                    put down the piece most recently lifted
+//	place Qe8                   put down a particular piece
+//	move e2 e4                  lift and place in one go
+//	slide Bf1 over e2/d3/c4     drag a piece across several squares
+//	wait 2s                     do nothing for a while
+//	clock 5m 5m                 connect a stopped clock (left, right)
+//	clock left|right|stop       start the clock for one side, or stop it
+//	lever                       press the clock lever
+//	button back|plus|run|minus|ok  press a button on the clock
+//	setup <fen>|start           set up a position without field updates
+//
+// A piece letter in front of a square (e.g. "Bf1") is checked against
+// what's actually there when lifting.
+func (sim *Simulator) Do(action string) error {
+	words := strings.Fields(action)
+	if len(words) == 0 {
+		return nil
+	}
+	arguments := words[1:]
+	switch words[0] {
+	case "lift":
+		if len(arguments) != 1 {
+			return errors.New("Usage: lift <square>")
+		}
+		return sim.liftPieceSquare(arguments[0])
+	case "place":
+		if len(arguments) != 1 {
+			return errors.New("Usage: place [piece]<square>")
+		}
+		piece, square, err := ParsePieceSquare(arguments[0])
+		if err != nil {
+			return err
+		}
+		return sim.Place(square, piece)
+	case "move":
+		if len(arguments) != 2 {
+			return errors.New("Usage: move <from> <to>")
+		}
+		err := sim.liftPieceSquare(arguments[0])
+		if err != nil {
+			return err
+		}
+		_, to, err := ParsePieceSquare(arguments[1])
+		if err != nil {
+			return err
+		}
+		return sim.Place(to, chess.NoPiece)
+	case "slide":
+		return sim.doSlide(arguments)
+	case "wait":
+		if len(arguments) != 1 {
+			return errors.New("Usage: wait <duration>")
+		}
+		d, err := time.ParseDuration(arguments[0])
+		if err != nil {
+			return err
+		}
+		time.Sleep(d)
+		return nil
+	case "clock":
+		return sim.doClock(arguments)
+	case "lever":
+		sim.PressLever()
+		return nil
+	case "button":
+		if len(arguments) != 1 {
+			return errors.New("Usage: button back|plus|run|minus|ok")
+		}
+		button, err := ParseClockButton(arguments[0])
+		if err != nil {
+			return err
+		}
+		sim.PressButton(button)
+		return nil
+	case "setup":
+		fen := strings.Join(arguments, " ")
+		if fen == "start" || fen == "" {
+			fen = StartingFen
+		}
+		board, err := chess.ParseFen(fen)
+		if err != nil {
+			return err
+		}
+		sim.SetPosition(board)
+		return nil
+	default:
+		return errors.New("Unknown action: " + words[0])
+	}
+}
+
+func (sim *Simulator) liftPieceSquare(text string) error {
+	square, err := sim.checkPieceSquare(text)
+	if err != nil {
+		return err
+	}
+	return sim.Lift(square)
+}
+
+// checkPieceSquare parses a square, optionally preceded by a piece
+// letter, and checks that the piece is actually there.
+func (sim *Simulator) checkPieceSquare(text string) (chess.Sq, error) {
+	piece, square, err := ParsePieceSquare(text)
+	if err != nil {
+		return chess.NoSquare, err
+	}
+	if piece != chess.NoPiece {
+		sim.mutex.Lock()
+		code := sim.pieces[getGdtFieldNumberFromChessSquare(square)]
+		sim.mutex.Unlock()
+		if code != getGdtPieceCodeByChessPiece(piece) {
+			return chess.NoSquare,
+				fmt.Errorf("%s is not on %s", text[:1], square)
+		}
+	}
+	return square, nil
+}
+
+func (sim *Simulator) doSlide(arguments []string) error {
+	// Accept both "slide Bf1 over e2/d3/c4" and "slide f1 e2 d3 c4".
+	if len(arguments) < 2 {
+		return errors.New("Usage: slide <from> over <square>/<square>/...")
+	}
+	from, err := sim.checkPieceSquare(arguments[0])
+	if err != nil {
+		return err
+	}
+	var path []chess.Sq
+	for _, argument := range arguments[1:] {
+		if argument == "over" {
+			continue
+		}
+		for _, name := range strings.Split(argument, "/") {
+			square, err := ParseSquare(name)
+			if err != nil {
+				return err
+			}
+			path = append(path, square)
+		}
+	}
+	return sim.Slide(from, path)
+}
+
+func (sim *Simulator) doClock(arguments []string) error {
+	if len(arguments) == 1 {
+		switch arguments[0] {
+		case "left":
+			sim.StartClock(true)
+		case "right":
+			sim.StartClock(false)
+		case "stop":
+			sim.StopClock()
+		default:
+			return errors.New("Usage: clock left|right|stop")
+		}
+		return nil
+	}
+	if len(arguments) != 2 {
+		return errors.New("Usage: clock <left time> <right time>")
+	}
+	left, err := time.ParseDuration(arguments[0])
+	if err != nil {
+		return err
+	}
+	right, err := time.ParseDuration(arguments[1])
+	if err != nil {
+		return err
+	}
+	sim.SetClock(left, right)
+	return nil
+}
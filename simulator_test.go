@@ -0,0 +1,107 @@
+package godgt
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestSimulatedSlide plays the example script from the README on the
+// simulator, and reads it back through a DgtBoard, a
+// StablePositionDetector and a MessageProcessor, as dgtd would. Each
+// line is left to settle before the next.
+func TestSimulatedSlide(t *testing.T) {
+	script := []string{
+		"lift e2, place e4",
+		"move e7 e5",
+		"slide Bf1 over e2/d3/c4",
+	}
+
+	boardEnd, simEnd := net.Pipe()
+	sim := NewSimulator(simEnd)
+	go sim.Run()
+	defer simEnd.Close()
+
+	dgtboard := NewDgtBoardFromTransport(boardEnd)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go dgtboard.Run(ctx)
+	detector := NewStablePositionDetector(dgtboard, 50*time.Millisecond)
+	go detector.Run()
+
+	mp := NewMessageProcessor()
+	dgtboard.WriteCommand(DGT_SEND_UPDATE_BRD)
+	dgtboard.WriteCommand(DGT_SEND_BRD)
+
+	// Processes messages until one satisfying done has been.
+	processUntil := func(what string, done func(*Message) bool) {
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case message := <-detector.Messages:
+				mp.ProcessMessage(message)
+				if done(message) {
+					return
+				}
+			case <-timeout:
+				t.Fatalf("timed out waiting for %s", what)
+			}
+		}
+	}
+	processUntil("the starting position", func(m *Message) bool {
+		return m.BoardUpdate != nil
+	})
+
+	var sans []string
+	for _, line := range script {
+		err := sim.RunScript(strings.NewReader(line), 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		processUntil(line+" to settle", func(m *Message) bool {
+			return m.StablePosition != nil
+		})
+
+		for len(mp.Events) > 0 {
+			event := <-mp.Events
+			if event.MoveEvent != nil {
+				if event.MoveEvent.Replaces {
+					t.Fatalf("%s replaced a move", event.MoveEvent.San)
+				}
+				sans = append(sans, event.MoveEvent.San)
+			}
+		}
+	}
+
+	if got := strings.Join(sans, " "); got != "e4 e5 Bc4" {
+		t.Fatalf("got %q, want %q", got, "e4 e5 Bc4")
+	}
+}
+
+// TestSimulatorRunTwice checks that a second Run is refused, rather
+// than closing the simulator down twice.
+func TestSimulatorRunTwice(t *testing.T) {
+	boardEnd, simEnd := net.Pipe()
+	sim := NewSimulator(simEnd)
+	finished := make(chan error)
+	go func() { finished <- sim.Run() }()
+
+	// Wait for the first Run to be answering commands.
+	boardEnd.Write([]byte{DGT_SEND_BRD})
+	reply := make([]byte, 3+64)
+	_, err := io.ReadFull(boardEnd, reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := sim.Run(); err != ERR_SIMULATOR_ALREADY_RUNNING {
+		t.Fatalf("second Run returned %v, want ERR_SIMULATOR_ALREADY_RUNNING", err)
+	}
+	boardEnd.Close()
+	if err := <-finished; err == nil {
+		t.Fatal("first Run returned nil after the transport was closed")
+	}
+}
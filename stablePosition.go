@@ -0,0 +1,106 @@
+package godgt
+
+import (
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// StablePosition is a board dump taken once the pieces have stopped
+// moving, so it can be trusted rather more than the field updates
+// leading up to it.
+type StablePosition struct {
+	Board *chess.Board
+	Time  time.Time
+}
+
+func NewStablePosition(board *chess.Board) *StablePosition {
+	return &StablePosition{
+		Board: board,
+		Time:  time.Now(),
+	}
+}
+
+func (sp *StablePosition) ToString() string {
+	return "Stable: " + sp.Board.Fen()
+}
+
+// DefaultQuietPeriod is long enough for a hand to have left the board,
+// but short enough that a recognised move doesn't feel slow to appear.
+const DefaultQuietPeriod = 500 * time.Millisecond
+
+// StablePositionDetector sits between a DgtBoard and whatever consumes
+// its messages. Field updates tend to arrive in bursts (and out of
+// order) while a piece is being moved; the detector waits until there
+// have been no field updates for QuietPeriod, then asks the board for
+// a complete dump, and sends it on as a StablePosition message.
+//
+// Every other message from the board is passed through to Messages
+// unchanged, apart from the dumps that the detector asked for itself,
+// which are replaced by the StablePosition messages.
+type StablePositionDetector struct {
+	dgtboard    *DgtBoard
+	QuietPeriod time.Duration
+	Messages    chan *Message
+}
+
+func NewStablePositionDetector(dgtboard *DgtBoard, quietPeriod time.Duration) *StablePositionDetector {
+	return &StablePositionDetector{
+		dgtboard:    dgtboard,
+		QuietPeriod: quietPeriod,
+		Messages:    make(chan *Message, 1024),
+	}
+}
+
+// Run reads messages from the board until the board stops (see
+// DgtBoard.Run), and then closes Messages. It should be run in its own
+// goroutine, alongside the board's Run or ReadLoop.
+func (spd *StablePositionDetector) Run() {
+	defer close(spd.Messages)
+
+	// Ticks once the board has been quiet for long enough; nil while
+	// there's nothing to wait for.
+	var quiet <-chan time.Time
+
+	// Set when we've asked for a dump and not yet received it.
+	// Dumps that we didn't ask for are passed straight through.
+	awaiting := false
+
+	// Set if a field has changed since the last dump was requested,
+	// in which case the dump is out of date by the time it arrives.
+	changed := false
+
+	for {
+		select {
+		case message, ok := <-spd.dgtboard.MessagesFromBoard:
+			if !ok {
+				return
+			}
+			if message.FieldUpdate != nil {
+				quiet = time.After(spd.QuietPeriod)
+				changed = true
+			}
+			if message.ResultSignal != nil && awaiting {
+				// With a magic piece on the board, this is
+				// what we get instead of the dump we asked
+				// for. Asking again would only get the same
+				// answer, so wait for a piece to move.
+				awaiting = false
+			}
+			if message.BoardUpdate != nil && awaiting {
+				awaiting = false
+				if !changed {
+					stablePosition := NewStablePosition(message.BoardUpdate.Board)
+					spd.Messages <- NewStablePositionMessage(stablePosition)
+				}
+				continue
+			}
+			spd.Messages <- message
+		case <-quiet:
+			quiet = nil
+			changed = false
+			awaiting = true
+			spd.dgtboard.WriteCommand(DGT_SEND_BRD)
+		}
+	}
+}
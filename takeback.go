@@ -0,0 +1,87 @@
+package godgt
+
+import (
+	"log"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// playedMove is a move in the game's history, with the position it was
+// played from.
+type playedMove struct {
+	Before *chess.Board
+	Move   chess.Move
+}
+
+// takeback checks whether the sensors show a position from earlier in
+// the game, and if so, goes back to it, reporting each move taken back
+// (the most recent first). If a position occurred more than once, the
+// most recent is taken.
+//
+// Putting the pieces back on their starting squares after a game of
+// more than one move is much more likely to be the start of a new game
+// than a takeback, so that's left for processStableBoard to deal with
+// as a new game.
+func (mp *MessageProcessor) takeback() bool {
+	index := -1
+	for i := len(mp.history) - 1; i >= 0; i-- {
+		if mp.history[i].Before.Piece == mp.sensors {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return false
+	}
+	if index == 0 && len(mp.history) > 1 && isStandardStart(mp.sensors) {
+		return false
+	}
+
+	for len(mp.history) > index {
+		mp.takeBackMove()
+	}
+	mp.clearAirState()
+	mp.awaitingTakeback = false
+
+	previous := mp.Inference.State
+	mp.Inference.Confirm(mp.Board)
+	mp.reportState(previous)
+	return true
+}
+
+// takeBackMove goes back to the position before the last move played,
+// and reports the move taken back.
+func (mp *MessageProcessor) takeBackMove() {
+	last := mp.history[len(mp.history)-1]
+	mp.history = mp.history[:len(mp.history)-1]
+	san := last.Move.San(last.Before)
+	log.Println("Move taken back: " + san)
+	mp.emit(NewTakebackEvent(&TakebackEvent{
+		Move:      last.Move,
+		San:       san,
+		FenBefore: mp.Board.Fen(),
+		FenAfter:  last.Before.Fen(),
+		Time:      time.Now(),
+	}))
+	mp.Board = last.Before
+}
+
+// takeBackGesture takes back the last move when the players ask for it
+// with a gesture, rather than by putting the pieces back. Until they
+// do put them back, the position is reported as diverged, so that the
+// move isn't simply seen again.
+func (mp *MessageProcessor) takeBackGesture() bool {
+	if len(mp.history) == 0 {
+		return false
+	}
+	mp.takeBackMove()
+	mp.clearAirState()
+	mp.awaitingTakeback = true
+
+	previous := mp.Inference.State
+	mp.Inference.Confirm(mp.Board)
+	mp.Inference.State = InferenceDiverged
+	mp.reportState(previous)
+	return true
+}
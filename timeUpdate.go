@@ -1,13 +1,110 @@
 package godgt
 
-// TimeUpdate encapsulates a raw time update from a DGT board.
+import (
+	"fmt"
+	"time"
+
+	"github.com/malbrecht/chess"
+)
+
+// PlayerClock is one player's half of a clock reading.
+type PlayerClock struct {
+	// The time left on the clock.
+	Remaining time.Duration
+
+	// The flag has fallen and the clock is blocked at zero.
+	FlagFallenAndBlocked bool
+
+	// The time per move indicator (e.g. Bronstein, Fischer) is on.
+	TimePerMove bool
+
+	// The flag has fallen and is shown on the display, but the clock
+	// might still be running (e.g. because the next time period has
+	// started).
+	FlagFallen bool
+}
+
+// TimeUpdate encapsulates a clock reading from a DGT board. The clock
+// itself only knows about its left and right players; which of those
+// is White depends on where the clock was put, so that's recorded in
+// WhiteOnRight.
 type TimeUpdate struct {
+	Left  PlayerClock
+	Right PlayerClock
+
+	// The clock is running (that is, not stopped with Start/Stop).
+	Running bool
+
+	// The lever is high on the right player's side (front view: /).
+	LeverRightHigh bool
+
+	// The clock is showing its low battery indicator.
+	BatteryLow bool
+
+	LeftToMove  bool
+	RightToMove bool
+
+	// White is the player on the right of the clock.
+	WhiteOnRight bool
+}
+
+// White returns White's half of the clock.
+func (tu *TimeUpdate) White() PlayerClock {
+	if tu.WhiteOnRight {
+		return tu.Right
+	}
+	return tu.Left
+}
+
+// Black returns Black's half of the clock.
+func (tu *TimeUpdate) Black() PlayerClock {
+	if tu.WhiteOnRight {
+		return tu.Left
+	}
+	return tu.Right
+}
+
+// Player returns the half of the clock belonging to a given side.
+func (tu *TimeUpdate) Player(color chess.Color) PlayerClock {
+	if color == chess.White {
+		return tu.White()
+	}
+	return tu.Black()
 }
 
-func NewTimeUpdate() *TimeUpdate {
-	return &TimeUpdate{}
+// SideToMove returns the side whose clock is (or would be) running.
+// The second return value is false if the clock doesn't say.
+func (tu *TimeUpdate) SideToMove() (chess.Color, bool) {
+	if tu.LeftToMove == tu.RightToMove {
+		return chess.White, false
+	}
+	if tu.LeftToMove != tu.WhiteOnRight {
+		return chess.White, true
+	}
+	return chess.Black, true
 }
 
 func (tu *TimeUpdate) ToString() string {
-	return "Time Update"
+	state := "stopped"
+	if tu.Running {
+		state = "running"
+	}
+	if side, ok := tu.SideToMove(); ok {
+		if side == chess.White {
+			state += ", White to move"
+		} else {
+			state += ", Black to move"
+		}
+	}
+	return fmt.Sprintf("White %s, Black %s (%s)",
+		FormatClockTime(tu.White().Remaining),
+		FormatClockTime(tu.Black().Remaining), state)
+}
+
+// FormatClockTime formats a duration the way a clock (and a PGN
+// [%clk] comment) shows it: h:mm:ss.
+func FormatClockTime(d time.Duration) string {
+	seconds := int(d / time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", seconds/3600, (seconds/60)%60,
+		seconds%60)
 }
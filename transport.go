@@ -0,0 +1,118 @@
+package godgt
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strings"
+)
+
+// Transport is the byte stream that a DgtBoard talks over. The board
+// protocol doesn't care what carries it, so anything that can be
+// read, written and closed will do: a serial port, a TCP socket, a
+// pseudo-terminal, a file of previously recorded bytes, or an
+// in-memory pipe.
+type Transport interface {
+	io.ReadWriteCloser
+}
+
+// OpenTransport opens a transport given a single name, which makes it
+// easy to select one from the command line. The following forms are
+// understood:
+//
+//	tcp:host:port   a TCP connection (e.g. to a network serial bridge)
+//	file:/path      a file of raw bytes previously recorded from a board
+//	/dev/ttyUSB0    anything else is opened as a serial port; this
+//	                also works for pseudo-terminals such as /dev/pts/3
+func OpenTransport(name string) (Transport, error) {
+	if strings.HasPrefix(name, "tcp:") {
+		return NewTCPTransport(strings.TrimPrefix(name, "tcp:"))
+	}
+	if strings.HasPrefix(name, "file:") {
+		return NewFileTransport(strings.TrimPrefix(name, "file:"))
+	}
+	return NewSerialTransport(name)
+}
+
+// NewSerialTransport opens a serial port (or pseudo-terminal) with the
+// settings that the DGT boards expect.
+func NewSerialTransport(portName string) (Transport, error) {
+	return CreatePort(portName)
+}
+
+// NewTCPTransport connects to a TCP socket, for example one exported
+// by ser2net or a similar serial-to-network bridge.
+func NewTCPTransport(address string) (Transport, error) {
+	return net.Dial("tcp", address)
+}
+
+// fileTransport replays a file of raw bytes recorded from a board.
+// Anything written to it (that is, commands intended for the board) is
+// silently discarded, since there is nobody on the other end to
+// listen.
+type fileTransport struct {
+	file *os.File
+}
+
+// NewFileTransport opens a file of recorded board output for replay.
+func NewFileTransport(filename string) (Transport, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	return &fileTransport{
+		file: file,
+	}, nil
+}
+
+func (ft *fileTransport) Read(p []byte) (int, error) {
+	return ft.file.Read(p)
+}
+
+func (ft *fileTransport) Write(p []byte) (int, error) {
+	return ioutil.Discard.Write(p)
+}
+
+func (ft *fileTransport) Close() error {
+	return ft.file.Close()
+}
+
+// pipeTransport is one end of an in-memory, full-duplex pipe.
+type pipeTransport struct {
+	reader *io.PipeReader
+	writer *io.PipeWriter
+}
+
+// NewPipeTransport returns the two ends of an in-memory, full-duplex
+// pipe. Bytes written to one end can be read from the other. This is
+// handy for connecting a DgtBoard to a simulated board, or for driving
+// the parser from tests.
+func NewPipeTransport() (Transport, Transport) {
+	aReader, bWriter := io.Pipe()
+	bReader, aWriter := io.Pipe()
+	a := &pipeTransport{
+		reader: aReader,
+		writer: aWriter,
+	}
+	b := &pipeTransport{
+		reader: bReader,
+		writer: bWriter,
+	}
+	return a, b
+}
+
+func (pt *pipeTransport) Read(p []byte) (int, error) {
+	return pt.reader.Read(p)
+}
+
+func (pt *pipeTransport) Write(p []byte) (int, error) {
+	return pt.writer.Write(p)
+}
+
+func (pt *pipeTransport) Close() error {
+	// Closing both halves means that a reader blocked on either
+	// side of the pipe will be woken up.
+	pt.writer.Close()
+	return pt.reader.Close()
+}
@@ -0,0 +1,194 @@
+// ucistub is a stand-in for a real UCI engine, for trying out (and
+// testing) the programs that use one, such as dgtplay, without having
+// to install an engine, and with replies that are the same every time.
+//
+// It plays the first legal move it finds, unless one of them mates,
+// and reports whatever score it's told to.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jessevdk/go-flags"
+	"github.com/kgigitdev/godgt"
+	"github.com/malbrecht/chess"
+)
+
+var opts struct {
+	Score int           `short:"s" long:"score" description:"The score (in centipawns, from the engine's point of view) to report for every move" default:"0"`
+	Delay time.Duration `short:"d" long:"delay" description:"How long to pretend to think for" default:"100ms"`
+	Log   string        `short:"l" long:"log" description:"Log the conversation with the GUI to this file"`
+}
+
+type stub struct {
+	board   *chess.Board
+	multiPV int
+	out     *bufio.Writer
+	log     *os.File
+
+	// The best move of an infinite search, to be sent on "stop".
+	pending string
+}
+
+func main() {
+	_, err := flags.ParseArgs(&opts, os.Args)
+	if err != nil {
+		os.Exit(1)
+	}
+
+	s := &stub{
+		multiPV: 1,
+		out:     bufio.NewWriter(os.Stdout),
+	}
+	if opts.Log != "" {
+		s.log, err = os.Create(opts.Log)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		defer s.log.Close()
+	}
+	s.board, _ = chess.ParseFen(godgt.StartingFen)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		s.logLine("< " + line)
+		if !s.handle(strings.Fields(line)) {
+			break
+		}
+		s.out.Flush()
+	}
+}
+
+// handle deals with one command from the GUI. It returns false if the
+// GUI has told us to quit.
+func (s *stub) handle(words []string) bool {
+	if len(words) == 0 {
+		return true
+	}
+	switch words[0] {
+	case "uci":
+		s.send("id name ucistub")
+		s.send("id author godgt")
+		s.send("option name Skill Level type spin default 20 min 0 max 20")
+		s.send("option name MultiPV type spin default 1 min 1 max 500")
+		s.send("uciok")
+	case "isready":
+		s.send("readyok")
+	case "setoption":
+		// setoption name MultiPV value 3
+		if len(words) == 5 && words[2] == "MultiPV" {
+			fmt.Sscan(words[4], &s.multiPV)
+		}
+	case "position":
+		s.position(words[1:])
+	case "go":
+		s.search(words[1:])
+	case "stop":
+		if s.pending != "" {
+			s.send("bestmove " + s.pending)
+			s.pending = ""
+		}
+	case "quit":
+		return false
+	}
+	// Anything else (ucinewgame, ...) needs no answer.
+	return true
+}
+
+// position handles "position startpos|fen <fen> [moves <moves>]".
+func (s *stub) position(words []string) {
+	var board *chess.Board
+	var err error
+	if len(words) > 0 && words[0] == "startpos" {
+		board, err = chess.ParseFen(godgt.StartingFen)
+		words = words[1:]
+	} else if len(words) >= 7 && words[0] == "fen" {
+		board, err = chess.ParseFen(strings.Join(words[1:7], " "))
+		words = words[7:]
+	} else {
+		s.send("info string bad position command")
+		return
+	}
+	if err != nil {
+		s.send("info string " + err.Error())
+		return
+	}
+	if len(words) > 0 && words[0] == "moves" {
+		for _, text := range words[1:] {
+			move, ok := findMove(board, text)
+			if !ok {
+				s.send("info string illegal move " + text)
+				return
+			}
+			board = board.MakeMove(move)
+		}
+	}
+	s.board = board
+}
+
+// search picks a move, and reports it as the result of a search of
+// depth 1. The "go" parameters are ignored, except "infinite", for
+// which the result is held back until the GUI says "stop".
+func (s *stub) search(words []string) {
+	moves := s.board.LegalMoves()
+	if len(moves) == 0 {
+		s.send("info depth 0 score mate 0")
+		s.send("bestmove (none)")
+		return
+	}
+
+	// Prefer a move that mates, so that games can be played to the
+	// end.
+	for i, move := range moves {
+		_, mate := s.board.MakeMove(move).IsCheckOrMate()
+		if mate {
+			moves[0], moves[i] = moves[i], moves[0]
+			break
+		}
+	}
+
+	time.Sleep(opts.Delay)
+	for rank := 1; rank <= s.multiPV && rank <= len(moves); rank++ {
+		s.send(fmt.Sprintf("info depth 1 multipv %d score cp %d nodes %d time %d pv %s",
+			rank, opts.Score, len(moves), opts.Delay/time.Millisecond,
+			moves[rank-1].String()))
+	}
+
+	for _, word := range words {
+		if word == "infinite" {
+			// A real engine would keep searching; we've nothing
+			// more to say, so just wait for "stop".
+			s.pending = moves[0].String()
+			return
+		}
+	}
+	s.send("bestmove " + moves[0].String())
+}
+
+func (s *stub) send(line string) {
+	s.logLine("> " + line)
+	s.out.WriteString(line + "\n")
+}
+
+func (s *stub) logLine(line string) {
+	if s.log != nil {
+		fmt.Fprintln(s.log, line)
+	}
+}
+
+// findMove finds the legal move written in UCI's long algebraic
+// notation, e.g. "e2e4" or "e7e8q".
+func findMove(board *chess.Board, text string) (chess.Move, bool) {
+	for _, move := range board.LegalMoves() {
+		if move.String() == text {
+			return move, true
+		}
+	}
+	return chess.Move{}, false
+}